@@ -0,0 +1,69 @@
+# S3 Access Point Example
+# This example demonstrates an access point that only accepts requests from one VPC
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+data "aws_caller_identity" "current" {}
+
+module "s3_bucket" {
+  source = "../../"
+
+  bucket_name = coalesce(var.bucket_name, "my-access-point-bucket-${random_string.bucket_suffix.result}")
+  environment = "prod"
+  purpose     = "internal-data"
+
+  force_destroy = true
+
+  access_points = {
+    (var.access_point_name) = {
+      vpc_id = aws_vpc.internal.id
+      policy = jsonencode({
+        Version = "2012-10-17"
+        Statement = [
+          {
+            Sid       = "AllowAccountRead"
+            Effect    = "Allow"
+            Principal = { AWS = "arn:aws:iam::${data.aws_caller_identity.current.account_id}:root" }
+            Action    = "s3:GetObject"
+            Resource  = "arn:aws:s3:${var.region}:${data.aws_caller_identity.current.account_id}:accesspoint/${var.access_point_name}/object/*"
+          }
+        ]
+      })
+    }
+  }
+
+  common_tags = {
+    Project     = "AccessPointExample"
+    Owner       = "Platform"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+# VPC the access point accepts requests from
+resource "aws_vpc" "internal" {
+  cidr_block = "10.20.0.0/16"
+
+  tags = {
+    Name = "access-point-example"
+  }
+}
+
+# Random string to ensure unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
@@ -0,0 +1,16 @@
+# Access Point Example Outputs
+
+output "bucket_name" {
+  description = "The name of the created S3 bucket"
+  value       = module.s3_bucket.bucket_id
+}
+
+output "access_point_arns" {
+  description = "The ARNs of the access points, keyed by name"
+  value       = module.s3_bucket.access_point_arns
+}
+
+output "vpc_id" {
+  description = "The ID of the VPC the access point is limited to"
+  value       = aws_vpc.internal.id
+}
@@ -0,0 +1,19 @@
+# Access Point Example Variables
+
+variable "region" {
+  description = "AWS region for the example resources"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "bucket_name" {
+  description = "The name of the S3 bucket. A random name is generated when null"
+  type        = string
+  default     = null
+}
+
+variable "access_point_name" {
+  description = "Name of the access point. Must be unique in the account and region"
+  type        = string
+  default     = "internal-reader"
+}
@@ -0,0 +1,102 @@
+# S3 Storage Class Analysis Example
+# This example demonstrates exporting storage class analysis to a separate reporting bucket
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+data "aws_caller_identity" "current" {}
+
+locals {
+  bucket_name           = coalesce(var.bucket_name, "my-analyzed-bucket-${random_string.bucket_suffix.result}")
+  analytics_bucket_name = "${local.bucket_name}-analytics"
+}
+
+# Reporting bucket receiving the analysis exports
+module "s3_analytics_bucket" {
+  source = "../../"
+
+  bucket_name = local.analytics_bucket_name
+  environment = "prod"
+  purpose     = "analytics-reports"
+
+  force_destroy = true
+
+  # Allow S3 to deliver analysis exports for the source bucket
+  bucket_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid    = "AnalyticsExport"
+        Effect = "Allow"
+        Principal = {
+          Service = "s3.amazonaws.com"
+        }
+        Action   = "s3:PutObject"
+        Resource = "arn:aws:s3:::${local.analytics_bucket_name}/*"
+        Condition = {
+          ArnLike = {
+            "aws:SourceArn" = "arn:aws:s3:::${local.bucket_name}"
+          }
+          StringEquals = {
+            "aws:SourceAccount" = data.aws_caller_identity.current.account_id
+            "s3:x-amz-acl"      = "bucket-owner-full-control"
+          }
+        }
+      }
+    ]
+  })
+
+  common_tags = {
+    Project     = "AnalyticsExample"
+    Owner       = "FinOps"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+# Bucket being analyzed
+module "s3_bucket" {
+  source = "../../"
+
+  bucket_name = local.bucket_name
+  environment = "prod"
+  purpose     = "application-data"
+
+  force_destroy = true
+
+  # Analyze the upload prefix before deciding on lifecycle transitions
+  analytics_configurations = {
+    uploads-access-patterns = {
+      filter_prefix = "uploads/"
+      export = {
+        destination_bucket_arn = module.s3_analytics_bucket.bucket_arn
+        prefix                 = "storage-class-analysis"
+      }
+    }
+  }
+
+  common_tags = {
+    Project     = "AnalyticsExample"
+    Owner       = "DevOps"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+# Random string to ensure unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
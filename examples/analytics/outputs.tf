@@ -0,0 +1,21 @@
+# Analytics Example Outputs
+
+output "bucket_name" {
+  description = "The name of the analyzed bucket"
+  value       = module.s3_bucket.bucket_id
+}
+
+output "analytics_bucket_name" {
+  description = "The name of the bucket receiving analysis exports"
+  value       = module.s3_analytics_bucket.bucket_id
+}
+
+output "analytics_bucket_arn" {
+  description = "The ARN of the bucket receiving analysis exports"
+  value       = module.s3_analytics_bucket.bucket_arn
+}
+
+output "analytics_configurations" {
+  description = "The names of the storage class analysis configurations"
+  value       = module.s3_bucket.bucket_analytics_configurations
+}
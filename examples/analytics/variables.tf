@@ -0,0 +1,13 @@
+# Analytics Example Variables
+
+variable "region" {
+  description = "AWS region for the example resources"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "bucket_name" {
+  description = "The name of the analyzed bucket. The reporting bucket name is derived from it. A random name is generated when null"
+  type        = string
+  default     = null
+}
@@ -12,17 +12,74 @@ terraform {
 }
 
 provider "aws" {
-  region = "us-east-1"
+  region = var.region
 }
 
 module "s3_bucket" {
   source = "../../"
 
-  bucket_name = "my-basic-bucket-${random_string.bucket_suffix.result}"
-  environment = "dev"
-  purpose     = "basic-storage"
+  create = var.create
 
-  common_tags = {
+  bucket_name   = var.bucket_prefix == null ? coalesce(var.bucket_name, "my-basic-bucket-${random_string.bucket_suffix.result}") : null
+  bucket_prefix = var.bucket_prefix
+  environment   = "dev"
+  purpose       = "basic-storage"
+
+  expected_region       = var.expected_region
+  expected_bucket_owner = var.expected_bucket_owner
+
+  force_destroy = true
+
+  versioning_status = var.versioning_status
+
+  object_ownership = var.object_ownership
+  acl              = var.acl
+  grants           = var.grants
+  lifecycle_rules  = var.lifecycle_rules
+  cors_rules       = var.cors_rules
+
+  abort_incomplete_multipart_upload_days = var.abort_incomplete_multipart_upload_days
+
+  transition_default_minimum_object_size = var.transition_default_minimum_object_size
+
+  security_profile = var.security_profile
+
+  encryption_algorithm = var.encryption_algorithm
+  create_kms_key       = var.create_kms_key
+  kms_key_arn          = var.kms_key_arn
+  kms_key_alias        = var.kms_key_alias
+  bucket_key_enabled   = var.bucket_key_enabled
+  encryption           = var.encryption
+
+  kms_key_deletion_window_in_days = var.kms_key_deletion_window_in_days
+  kms_key_enable_rotation         = var.kms_key_enable_rotation
+  kms_key_tags                    = var.kms_key_tags
+
+  bucket_policy = var.bucket_policy
+  enforce_ssl   = var.enforce_ssl
+
+  enforce_min_tls_version      = var.enforce_min_tls_version
+  cloudfront_distribution_arns = var.cloudfront_distribution_arns
+  restrict_to_vpc_endpoints    = var.restrict_to_vpc_endpoints
+
+  cross_account_read_principals = var.cross_account_read_principals
+
+  replication_configuration        = var.replication_configuration
+  create_replication_latency_alarm = var.create_replication_latency_alarm
+
+  deny_unencrypted_uploads      = var.deny_unencrypted_uploads
+  required_upload_sse_algorithm = var.required_upload_sse_algorithm
+
+  object_lock_enabled                      = var.object_lock_enabled
+  object_lock_governance_bypass_principals = var.object_lock_governance_bypass_principals
+
+  eventbridge_enabled = var.eventbridge_enabled
+
+  manage_public_access_block = var.manage_public_access_block
+
+  tags = var.tags
+
+  default_tags = {
     Project     = "BasicExample"
     Owner       = "DevOps"
     CostCenter  = "IT"
@@ -2,7 +2,7 @@
 
 output "bucket_name" {
   description = "The name of the created S3 bucket"
-  value       = module.s3_bucket.bucket_id
+  value       = module.s3_bucket.bucket_name
 }
 
 output "bucket_arn" {
@@ -15,6 +15,26 @@ output "bucket_domain_name" {
   value       = module.s3_bucket.bucket_domain_name
 }
 
+output "bucket_regional_domain_name" {
+  description = "The region-specific domain name of the bucket"
+  value       = module.s3_bucket.bucket_regional_domain_name
+}
+
+output "bucket_hosted_zone_id" {
+  description = "The Route 53 hosted zone ID of the bucket"
+  value       = module.s3_bucket.bucket_hosted_zone_id
+}
+
+output "bucket_region" {
+  description = "The AWS region of the bucket"
+  value       = module.s3_bucket.bucket_region
+}
+
+output "bucket" {
+  description = "The bucket attributes as a single object"
+  value       = module.s3_bucket.bucket
+}
+
 output "bucket_versioning_status" {
   description = "The versioning status of the bucket"
   value       = module.s3_bucket.bucket_versioning_status
@@ -23,4 +43,60 @@ output "bucket_versioning_status" {
 output "bucket_encryption_algorithm" {
   description = "The encryption algorithm used"
   value       = module.s3_bucket.bucket_encryption_algorithm
-} 
\ No newline at end of file
+}
+
+output "bucket_key_enabled" {
+  description = "Whether bucket keys are enabled for SSE-KMS"
+  value       = module.s3_bucket.bucket_key_enabled
+}
+
+output "bucket_ownership_controls" {
+  description = "The object ownership setting of the bucket"
+  value       = module.s3_bucket.bucket_ownership_controls
+}
+
+
+output "kms_key_arn" {
+  description = "The ARN of the KMS key used for encryption"
+  value       = module.s3_bucket.kms_key_arn
+}
+
+output "bucket_policy_json" {
+  description = "The JSON policy document applied to the bucket"
+  value       = module.s3_bucket.bucket_policy_json
+}
+
+output "effective_tags" {
+  description = "The merged tags applied to the bucket"
+  value       = module.s3_bucket.effective_tags
+}
+
+output "object_lock_enabled" {
+  description = "Whether object lock is enabled on the bucket"
+  value       = module.s3_bucket.object_lock_enabled
+}
+
+output "versioning_status" {
+  description = "The versioning state of the bucket"
+  value       = module.s3_bucket.versioning_status
+}
+
+output "all_resource_arns" {
+  description = "Every ARN created by the module, for scoping IAM policies"
+  value       = module.s3_bucket.all_resource_arns
+}
+
+output "feature_summary" {
+  description = "The features in effect on the bucket"
+  value       = module.s3_bucket.feature_summary
+}
+
+output "acceleration_status" {
+  description = "The transfer acceleration status of the bucket, null when not configured"
+  value       = module.s3_bucket.acceleration_status
+}
+
+output "request_payer" {
+  description = "Who pays for requests on the bucket"
+  value       = module.s3_bucket.request_payer
+}
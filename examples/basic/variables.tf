@@ -0,0 +1,235 @@
+# Basic Example Variables
+
+variable "region" {
+  description = "AWS region for the example resources"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "create" {
+  description = "Whether the module creates the bucket"
+  type        = bool
+  default     = true
+}
+
+variable "bucket_name" {
+  description = "The name of the S3 bucket. A random name is generated when null"
+  type        = string
+  default     = null
+}
+
+variable "bucket_prefix" {
+  description = "Prefix for an AWS-generated bucket name. Takes the place of bucket_name when set"
+  type        = string
+  default     = null
+}
+
+variable "expected_bucket_owner" {
+  description = "Account ID expected to own the bucket"
+  type        = string
+  default     = null
+}
+
+variable "expected_region" {
+  description = "Region the bucket must be created in"
+  type        = string
+  default     = null
+}
+
+variable "object_ownership" {
+  description = "Object ownership setting for the bucket"
+  type        = string
+  default     = "BucketOwnerEnforced"
+}
+
+variable "acl" {
+  description = "Canned ACL for the bucket. Requires object_ownership other than BucketOwnerEnforced"
+  type        = string
+  default     = null
+}
+
+variable "grants" {
+  description = "Explicit ACL grants for the bucket, passed through to the module's grants variable"
+  type        = any
+  default     = []
+}
+
+variable "lifecycle_rules" {
+  description = "Lifecycle rules for the bucket, passed through to the module's lifecycle_rules variable"
+  type        = any
+  default     = []
+}
+
+variable "manage_public_access_block" {
+  description = "Whether the module manages the bucket-level public access block"
+  type        = bool
+  default     = true
+}
+
+variable "cors_rules" {
+  description = "CORS rules for the bucket, passed through to the module's cors_rules variable"
+  type        = any
+  default     = []
+}
+
+variable "abort_incomplete_multipart_upload_days" {
+  description = "Days after which incomplete multipart uploads are aborted"
+  type        = number
+  default     = null
+}
+
+variable "security_profile" {
+  description = "Hardening preset passed to the module: none, baseline or strict"
+  type        = string
+  default     = "none"
+}
+
+variable "transition_default_minimum_object_size" {
+  description = "Minimum object size for lifecycle transitions"
+  type        = string
+  default     = null
+}
+
+variable "encryption_algorithm" {
+  description = "The server-side encryption algorithm to use. Null leaves the choice to security_profile"
+  type        = string
+  default     = null
+}
+
+variable "bucket_key_enabled" {
+  description = "Whether S3 Bucket Keys are used for SSE-KMS"
+  type        = bool
+  default     = true
+}
+
+variable "create_kms_key" {
+  description = "Whether the module should create a dedicated KMS key. Null leaves the choice to security_profile"
+  type        = bool
+  default     = null
+}
+
+variable "kms_key_deletion_window_in_days" {
+  description = "Waiting period in days before the created KMS key is deleted"
+  type        = number
+  default     = 30
+}
+
+variable "kms_key_enable_rotation" {
+  description = "Whether automatic rotation is enabled for the created KMS key"
+  type        = bool
+  default     = true
+}
+
+variable "kms_key_tags" {
+  description = "Additional tags for the created KMS key"
+  type        = map(string)
+  default     = {}
+}
+
+variable "bucket_policy" {
+  description = "Optional JSON bucket policy to attach to the bucket"
+  type        = string
+  default     = null
+}
+
+variable "enforce_ssl" {
+  description = "Whether to deny requests that do not use TLS. Null leaves the choice to security_profile"
+  type        = bool
+  default     = null
+}
+
+variable "enforce_min_tls_version" {
+  description = "Minimum TLS version for requests to the bucket"
+  type        = string
+  default     = null
+}
+
+variable "deny_unencrypted_uploads" {
+  description = "Whether to deny uploads that do not request server-side encryption"
+  type        = bool
+  default     = false
+}
+
+variable "required_upload_sse_algorithm" {
+  description = "Server-side encryption algorithm uploads must request"
+  type        = string
+  default     = null
+}
+
+variable "tags" {
+  description = "Per-bucket tags that override the example's default tags"
+  type        = map(string)
+  default     = {}
+}
+
+variable "versioning_status" {
+  description = "Versioning status for the bucket"
+  type        = string
+  default     = "Enabled"
+}
+
+variable "kms_key_arn" {
+  description = "ARN of an existing KMS key to use for SSE-KMS"
+  type        = string
+  default     = null
+}
+
+variable "kms_key_alias" {
+  description = "Alias of an existing KMS key to use for SSE-KMS"
+  type        = string
+  default     = null
+}
+
+variable "encryption" {
+  description = "Default encryption settings passed to the module. Overrides encryption_algorithm and kms_key_arn when set"
+  type        = any
+  default     = null
+}
+
+variable "replication_configuration" {
+  description = "Replication configuration passed through to the module's replication_configuration variable"
+  type        = any
+  default     = null
+}
+
+variable "create_replication_latency_alarm" {
+  description = "Whether to create replication latency alarms for rules with replication metrics"
+  type        = bool
+  default     = false
+}
+
+variable "cross_account_read_principals" {
+  description = "AWS account IDs or IAM ARNs that may read the bucket"
+  type        = list(string)
+  default     = []
+}
+
+variable "object_lock_enabled" {
+  description = "Whether object lock is enabled on the bucket. null uses the security profile default"
+  type        = bool
+  default     = null
+}
+
+variable "object_lock_governance_bypass_principals" {
+  description = "IAM ARNs that may bypass GOVERNANCE mode retention"
+  type        = list(string)
+  default     = []
+}
+
+variable "cloudfront_distribution_arns" {
+  description = "CloudFront distributions using origin access control that may read objects"
+  type        = list(string)
+  default     = []
+}
+
+variable "restrict_to_vpc_endpoints" {
+  description = "VPC endpoint IDs that object access must come through"
+  type        = list(string)
+  default     = []
+}
+
+variable "eventbridge_enabled" {
+  description = "Whether to send bucket events to Amazon EventBridge"
+  type        = bool
+  default     = false
+}
@@ -0,0 +1,79 @@
+# S3 Batch Operations Manifest Example
+# This example demonstrates a bucket holding Batch Operations manifests and completion reports that clean themselves up
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+locals {
+  bucket_name = coalesce(var.bucket_name, "my-batch-manifests-${random_string.bucket_suffix.result}")
+}
+
+module "s3_batch_manifests" {
+  source = "../../"
+
+  bucket_name = local.bucket_name
+  environment = "prod"
+  purpose     = "batch-operations"
+
+  force_destroy = true
+
+  # Manifests are only needed while a job runs; reports are kept long enough to review failures
+  lifecycle_rules = [
+    {
+      id     = "expire-manifests"
+      status = "Enabled"
+      filter = {
+        prefix = var.manifest_prefix
+      }
+      expiration = {
+        days = var.manifest_expiration_days
+      }
+      noncurrent_version_expiration = {
+        noncurrent_days = 1
+      }
+    },
+    {
+      id     = "expire-reports"
+      status = "Enabled"
+      filter = {
+        prefix = var.report_prefix
+      }
+      expiration = {
+        days = var.report_expiration_days
+      }
+      noncurrent_version_expiration = {
+        noncurrent_days = 1
+      }
+    },
+    {
+      id                                     = "abort-incomplete-uploads"
+      status                                 = "Enabled"
+      abort_incomplete_multipart_upload_days = 1
+    }
+  ]
+
+  common_tags = {
+    Project     = "BatchOperationsExample"
+    Owner       = "DataEngineering"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+# Random string to ensure unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
@@ -0,0 +1,21 @@
+# Batch Operations Manifest Example Outputs
+
+output "bucket_name" {
+  description = "The name of the manifest bucket"
+  value       = module.s3_batch_manifests.bucket_id
+}
+
+output "bucket_arn" {
+  description = "The ARN of the manifest bucket, for the job manifest location and report bucket"
+  value       = module.s3_batch_manifests.bucket_arn
+}
+
+output "manifest_prefix" {
+  description = "Prefix to upload job manifests under"
+  value       = var.manifest_prefix
+}
+
+output "report_prefix" {
+  description = "Prefix to pass as the job completion report prefix"
+  value       = trimsuffix(var.report_prefix, "/")
+}
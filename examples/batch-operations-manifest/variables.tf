@@ -0,0 +1,37 @@
+# Batch Operations Manifest Example Variables
+
+variable "region" {
+  description = "AWS region for the example resources"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "bucket_name" {
+  description = "The name of the manifest bucket. A random name is generated when null"
+  type        = string
+  default     = null
+}
+
+variable "manifest_prefix" {
+  description = "Prefix that job manifests are uploaded under"
+  type        = string
+  default     = "manifests/"
+}
+
+variable "report_prefix" {
+  description = "Prefix that Batch Operations writes completion reports under"
+  type        = string
+  default     = "reports/"
+}
+
+variable "manifest_expiration_days" {
+  description = "Days after which manifests are expired"
+  type        = number
+  default     = 7
+}
+
+variable "report_expiration_days" {
+  description = "Days after which completion reports are expired"
+  type        = number
+  default     = 30
+}
@@ -0,0 +1,52 @@
+# S3 CloudFront Origin Access Identity Example
+# This example demonstrates a private bucket readable only through a CloudFront origin access identity
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+resource "aws_cloudfront_origin_access_identity" "this" {
+  comment = "Access to ${local.bucket_name}"
+}
+
+locals {
+  bucket_name = coalesce(var.bucket_name, "my-cloudfront-origin-${random_string.bucket_suffix.result}")
+}
+
+module "s3_origin" {
+  source = "../../"
+
+  bucket_name = local.bucket_name
+  environment = "prod"
+  purpose     = "cloudfront-origin"
+
+  force_destroy = true
+
+  # The bucket stays private; only the OAI may read objects, and only over TLS
+  cloudfront_oai_iam_arns = [aws_cloudfront_origin_access_identity.this.iam_arn]
+  enforce_ssl             = true
+
+  common_tags = {
+    Project     = "CloudFrontOAIExample"
+    Owner       = "DevOps"
+    CostCenter  = "Marketing"
+    Environment = "Production"
+  }
+}
+
+# Random string to ensure unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
@@ -0,0 +1,21 @@
+# CloudFront OAI Example Outputs
+
+output "bucket_name" {
+  description = "The name of the origin bucket"
+  value       = module.s3_origin.bucket_id
+}
+
+output "bucket_regional_domain_name" {
+  description = "The regional domain name to use as the CloudFront origin"
+  value       = module.s3_origin.bucket_regional_domain_name
+}
+
+output "cloudfront_oai_id" {
+  description = "The ID of the origin access identity"
+  value       = aws_cloudfront_origin_access_identity.this.id
+}
+
+output "cloudfront_oai_iam_arn" {
+  description = "The IAM ARN of the origin access identity granted read access"
+  value       = aws_cloudfront_origin_access_identity.this.iam_arn
+}
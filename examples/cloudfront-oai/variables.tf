@@ -0,0 +1,13 @@
+# CloudFront OAI Example Variables
+
+variable "region" {
+  description = "AWS region for the example resources"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "bucket_name" {
+  description = "The name of the origin bucket. A random name is generated when null"
+  type        = string
+  default     = null
+}
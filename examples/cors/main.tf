@@ -0,0 +1,57 @@
+# S3 CORS Example
+# This example demonstrates a bucket that accepts direct browser uploads from a single-page application
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+module "s3_cors" {
+  source = "../../"
+
+  bucket_name = coalesce(var.bucket_name, "my-cors-bucket-${random_string.bucket_suffix.result}")
+  environment = "dev"
+  purpose     = "spa-uploads"
+
+  force_destroy = true
+
+  cors_rules = [
+    {
+      id              = "browser-uploads"
+      allowed_headers = ["*"]
+      allowed_methods = ["PUT", "POST"]
+      allowed_origins = var.allowed_origins
+      expose_headers  = ["ETag"]
+      max_age_seconds = 3000
+    },
+    {
+      id              = "public-reads"
+      allowed_headers = []
+      allowed_methods = ["GET", "HEAD"]
+      allowed_origins = ["*"]
+    }
+  ]
+
+  common_tags = {
+    Project     = "CorsExample"
+    Owner       = "DevOps"
+    CostCenter  = "IT"
+    Environment = "Development"
+  }
+}
+
+# Random string to ensure unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
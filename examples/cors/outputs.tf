@@ -0,0 +1,16 @@
+# CORS Example Outputs
+
+output "bucket_name" {
+  description = "The name of the created S3 bucket"
+  value       = module.s3_cors.bucket_id
+}
+
+output "bucket_arn" {
+  description = "The ARN of the created S3 bucket"
+  value       = module.s3_cors.bucket_arn
+}
+
+output "cors_configuration" {
+  description = "The CORS configuration of the bucket"
+  value       = module.s3_cors.bucket_cors_configuration
+}
@@ -0,0 +1,19 @@
+# CORS Example Variables
+
+variable "region" {
+  description = "AWS region for the example resources"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "bucket_name" {
+  description = "The name of the S3 bucket. A random name is generated when null"
+  type        = string
+  default     = null
+}
+
+variable "allowed_origins" {
+  description = "Origins allowed to upload objects from the browser"
+  type        = list(string)
+  default     = ["https://app.example.com"]
+}
@@ -0,0 +1,71 @@
+# S3 Cross-Region Example
+# This example demonstrates creating buckets in two regions by passing a provider alias to a second module instance
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+# The module uses a single default aws provider, so another region only needs an alias passed through providers
+provider "aws" {
+  alias  = "destination"
+  region = var.destination_region
+}
+
+locals {
+  bucket_name = coalesce(var.bucket_name, "my-cross-region-bucket-${random_string.bucket_suffix.result}")
+}
+
+module "s3_source" {
+  source = "../../"
+
+  bucket_name = local.bucket_name
+  environment = "prod"
+  purpose     = "primary-storage"
+
+  force_destroy = true
+
+  common_tags = {
+    Project     = "CrossRegionExample"
+    Owner       = "DevOps"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+module "s3_destination" {
+  source = "../../"
+
+  providers = {
+    aws = aws.destination
+  }
+
+  bucket_name = "${local.bucket_name}-${var.destination_region}"
+  environment = "prod"
+  purpose     = "secondary-storage"
+
+  force_destroy = true
+
+  common_tags = {
+    Project     = "CrossRegionExample"
+    Owner       = "DevOps"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+# Random string to ensure unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
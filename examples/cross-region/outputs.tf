@@ -0,0 +1,26 @@
+# Cross-Region Example Outputs
+
+output "source_bucket_name" {
+  description = "The name of the source bucket"
+  value       = module.s3_source.bucket_id
+}
+
+output "source_bucket_region" {
+  description = "The region of the source bucket"
+  value       = module.s3_source.bucket_region
+}
+
+output "destination_bucket_name" {
+  description = "The name of the destination bucket"
+  value       = module.s3_destination.bucket_id
+}
+
+output "destination_bucket_region" {
+  description = "The region of the destination bucket"
+  value       = module.s3_destination.bucket_region
+}
+
+output "destination_bucket_arn" {
+  description = "The ARN of the destination bucket, for replication destinations in the source module"
+  value       = module.s3_destination.bucket_arn
+}
@@ -0,0 +1,19 @@
+# Cross-Region Example Variables
+
+variable "region" {
+  description = "AWS region for the source bucket"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "destination_region" {
+  description = "AWS region for the destination bucket"
+  type        = string
+  default     = "us-west-2"
+}
+
+variable "bucket_name" {
+  description = "The name of the source bucket. The destination bucket adds the destination region as a suffix. A random name is generated when null"
+  type        = string
+  default     = null
+}
@@ -12,89 +12,25 @@ terraform {
 }
 
 provider "aws" {
-  region = "us-east-1"
+  region = var.region
 }
 
 module "s3_data_lake" {
   source = "../../"
 
-  bucket_name = "my-data-lake-${random_string.bucket_suffix.result}"
+  bucket_name = coalesce(var.bucket_name, "my-data-lake-${random_string.bucket_suffix.result}")
   environment = "prod"
   purpose     = "data-lake"
 
+  force_destroy = true
+
   # Enhanced encryption for data lake
   encryption_algorithm = "aws:kms"
+  create_kms_key       = true
   bucket_key_enabled  = true
 
   # Comprehensive lifecycle rules for data lake
-  lifecycle_rules = [
-    {
-      id     = "raw-data-transition"
-      status = "Enabled"
-      filter = {
-        prefix = "raw/"
-      }
-      transitions = [
-        {
-          days          = 30
-          storage_class = "STANDARD_IA"
-        },
-        {
-          days          = 90
-          storage_class = "GLACIER"
-        },
-        {
-          days          = 365
-          storage_class = "DEEP_ARCHIVE"
-        }
-      ]
-      noncurrent_version_transitions = [
-        {
-          noncurrent_days = 30
-          storage_class   = "STANDARD_IA"
-        },
-        {
-          noncurrent_days = 90
-          storage_class   = "GLACIER"
-        }
-      ]
-      noncurrent_version_expiration = {
-        noncurrent_days = 2555
-      }
-    },
-    {
-      id     = "processed-data-transition"
-      status = "Enabled"
-      filter = {
-        prefix = "processed/"
-      }
-      transitions = [
-        {
-          days          = 90
-          storage_class = "STANDARD_IA"
-        },
-        {
-          days          = 180
-          storage_class = "GLACIER"
-        }
-      ]
-    },
-    {
-      id     = "temp-data-expiration"
-      status = "Enabled"
-      filter = {
-        prefix = "temp/"
-      }
-      expiration = {
-        days = 7
-      }
-    },
-    {
-      id     = "incomplete-multipart-cleanup"
-      status = "Enabled"
-      abort_incomplete_multipart_upload_days = 1
-    }
-  ]
+  lifecycle_rules = var.lifecycle_rules
 
   # Intelligent tiering for cost optimization
   intelligent_tiering_configurations = [
@@ -107,7 +43,7 @@ module "s3_data_lake" {
           days        = 180
         },
         {
-          access_tier = "INTELLIGENT_TIERING"
+          access_tier = "ARCHIVE_ACCESS"
           days        = 90
         }
       ]
@@ -139,6 +75,7 @@ module "s3_data_lake" {
   ]
 
   # Object lock for compliance
+  object_lock_enabled = true
   object_lock_configuration = {
     rules = [{
       default_retention = {
@@ -38,4 +38,19 @@ output "data_lake_object_lock_configuration" {
 output "data_lake_notification_configuration" {
   description = "The notification configuration of the data lake bucket"
   value       = module.s3_data_lake.bucket_notification_configuration
-} 
\ No newline at end of file
+}
+
+output "data_lake_kms_key_arn" {
+  description = "The ARN of the KMS key created for the data lake"
+  value       = module.s3_data_lake.kms_key_arn
+}
+
+output "data_lake_object_lock_enabled" {
+  description = "Whether object lock is enabled on the data lake bucket"
+  value       = module.s3_data_lake.object_lock_enabled
+}
+
+output "data_lake_feature_summary" {
+  description = "The features in effect on the data lake bucket"
+  value       = module.s3_data_lake.feature_summary
+}
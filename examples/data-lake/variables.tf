@@ -0,0 +1,86 @@
+# Data Lake Example Variables
+
+variable "region" {
+  description = "AWS region for the example resources"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "bucket_name" {
+  description = "The name of the data lake bucket. A random name is generated when null"
+  type        = string
+  default     = null
+}
+
+variable "lifecycle_rules" {
+  description = "Lifecycle rules for the data lake zones, passed through to the module's lifecycle_rules variable"
+  type        = any
+  default = [
+    {
+      id     = "raw-data-transition"
+      status = "Enabled"
+      filter = {
+        prefix = "raw/"
+      }
+      transitions = [
+        {
+          days          = 30
+          storage_class = "STANDARD_IA"
+        },
+        {
+          days          = 90
+          storage_class = "GLACIER"
+        },
+        {
+          days          = 365
+          storage_class = "DEEP_ARCHIVE"
+        }
+      ]
+      noncurrent_version_transitions = [
+        {
+          noncurrent_days = 30
+          storage_class   = "STANDARD_IA"
+        },
+        {
+          noncurrent_days = 90
+          storage_class   = "GLACIER"
+        }
+      ]
+      noncurrent_version_expiration = {
+        noncurrent_days = 2555
+      }
+    },
+    {
+      id     = "processed-data-transition"
+      status = "Enabled"
+      filter = {
+        prefix = "processed/"
+      }
+      transitions = [
+        {
+          days          = 90
+          storage_class = "STANDARD_IA"
+        },
+        {
+          days          = 180
+          storage_class = "GLACIER"
+        }
+      ]
+    },
+    {
+      id     = "temp-data-expiration"
+      status = "Enabled"
+      filter = {
+        prefix = "temp/"
+      }
+      expiration = {
+        days = 7
+      }
+    },
+    {
+      id                                     = "incomplete-multipart-cleanup"
+      status                                 = "Enabled"
+      abort_incomplete_multipart_upload_days = 1
+    }
+  ]
+}
@@ -0,0 +1,158 @@
+# S3 Governed Data Example
+# This example demonstrates a private data-sharing bucket with KMS encryption, access logging and daily inventory
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+data "aws_caller_identity" "current" {}
+
+locals {
+  bucket_name           = coalesce(var.bucket_name, "my-governed-data-${random_string.bucket_suffix.result}")
+  log_bucket_name       = "${local.bucket_name}-logs"
+  inventory_bucket_name = "${local.bucket_name}-inventory"
+}
+
+# Bucket receiving the server access logs
+module "s3_log_bucket" {
+  source = "../../"
+
+  bucket_name = local.log_bucket_name
+  environment = "prod"
+  purpose     = "access-logs"
+
+  force_destroy = true
+
+  # Allow the S3 logging service to deliver logs for the governed bucket
+  bucket_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid    = "S3ServerAccessLogsPolicy"
+        Effect = "Allow"
+        Principal = {
+          Service = "logging.s3.amazonaws.com"
+        }
+        Action   = "s3:PutObject"
+        Resource = "arn:aws:s3:::${local.log_bucket_name}/*"
+        Condition = {
+          ArnLike = {
+            "aws:SourceArn" = "arn:aws:s3:::${local.bucket_name}"
+          }
+          StringEquals = {
+            "aws:SourceAccount" = data.aws_caller_identity.current.account_id
+          }
+        }
+      }
+    ]
+  })
+
+  common_tags = {
+    Project     = "GovernedDataExample"
+    Owner       = "Security"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+# Bucket receiving the inventory reports
+module "s3_inventory_bucket" {
+  source = "../../"
+
+  bucket_name = local.inventory_bucket_name
+  environment = "prod"
+  purpose     = "inventory-reports"
+
+  force_destroy = true
+
+  # Allow S3 to deliver inventory reports for the governed bucket
+  bucket_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid    = "InventoryDelivery"
+        Effect = "Allow"
+        Principal = {
+          Service = "s3.amazonaws.com"
+        }
+        Action   = "s3:PutObject"
+        Resource = "arn:aws:s3:::${local.inventory_bucket_name}/*"
+        Condition = {
+          ArnLike = {
+            "aws:SourceArn" = "arn:aws:s3:::${local.bucket_name}"
+          }
+          StringEquals = {
+            "aws:SourceAccount" = data.aws_caller_identity.current.account_id
+            "s3:x-amz-acl"      = "bucket-owner-full-control"
+          }
+        }
+      }
+    ]
+  })
+
+  common_tags = {
+    Project     = "GovernedDataExample"
+    Owner       = "Audit"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+# Private, versioned, KMS-encrypted bucket shared with data consumers
+module "s3_bucket" {
+  source = "../../"
+
+  bucket_name = local.bucket_name
+  environment = "prod"
+  purpose     = "shared-datasets"
+
+  force_destroy = true
+
+  versioning_status = "Enabled"
+
+  encryption_algorithm = "aws:kms"
+  create_kms_key       = true
+  bucket_key_enabled   = true
+
+  enforce_ssl = true
+
+  logging = {
+    target_bucket = module.s3_log_bucket.bucket_id
+    target_prefix = "access-logs/${local.bucket_name}/"
+  }
+
+  inventory_configurations = {
+    daily-governance = {
+      included_object_versions = "All"
+      schedule_frequency       = "Daily"
+      destination_bucket_arn   = module.s3_inventory_bucket.bucket_arn
+      destination_prefix       = "inventory"
+      destination_format       = "CSV"
+      optional_fields          = ["Size", "LastModifiedDate", "StorageClass", "EncryptionStatus", "BucketKeyStatus"]
+    }
+  }
+
+  common_tags = {
+    Project     = "GovernedDataExample"
+    Owner       = "DataPlatform"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+# Random string to ensure unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
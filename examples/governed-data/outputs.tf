@@ -0,0 +1,31 @@
+# Governed Data Example Outputs
+
+output "bucket_name" {
+  description = "The name of the governed bucket"
+  value       = module.s3_bucket.bucket_id
+}
+
+output "kms_key_arn" {
+  description = "The ARN of the KMS key encrypting the governed bucket"
+  value       = module.s3_bucket.kms_key_arn
+}
+
+output "log_bucket_name" {
+  description = "The name of the bucket receiving access logs"
+  value       = module.s3_log_bucket.bucket_id
+}
+
+output "logging_target_prefix" {
+  description = "The prefix under which access logs are delivered"
+  value       = module.s3_bucket.bucket_logging_target.target_prefix
+}
+
+output "inventory_bucket_name" {
+  description = "The name of the bucket receiving inventory reports"
+  value       = module.s3_inventory_bucket.bucket_id
+}
+
+output "inventory_bucket_arn" {
+  description = "The ARN of the bucket receiving inventory reports"
+  value       = module.s3_inventory_bucket.bucket_arn
+}
@@ -0,0 +1,13 @@
+# Governed Data Example Variables
+
+variable "region" {
+  description = "AWS region for the example resources"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "bucket_name" {
+  description = "The name of the governed bucket. The log and inventory bucket names are derived from it. A random name is generated when null"
+  type        = string
+  default     = null
+}
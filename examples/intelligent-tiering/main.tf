@@ -0,0 +1,76 @@
+# S3 Intelligent-Tiering Example
+# This example demonstrates archiving objects with unpredictable access patterns automatically
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+module "s3_bucket" {
+  source = "../../"
+
+  bucket_name = coalesce(var.bucket_name, "my-tiering-bucket-${random_string.bucket_suffix.result}")
+  environment = "prod"
+  purpose     = "archive-storage"
+
+  force_destroy = true
+
+  intelligent_tiering_configurations = [
+    {
+      id   = "entire-bucket"
+      name = "EntireBucket"
+      tiering = [
+        {
+          access_tier = "ARCHIVE_ACCESS"
+          days        = 90
+        },
+        {
+          access_tier = "DEEP_ARCHIVE_ACCESS"
+          days        = 180
+        }
+      ]
+    },
+    {
+      id   = "reports"
+      name = "Reports"
+      filter = {
+        prefix = "reports/"
+        tags = [
+          {
+            key   = "retention"
+            value = "long-term"
+          }
+        ]
+      }
+      tiering = [
+        {
+          access_tier = "DEEP_ARCHIVE_ACCESS"
+          days        = 365
+        }
+      ]
+    }
+  ]
+
+  common_tags = {
+    Project     = "IntelligentTieringExample"
+    Owner       = "DevOps"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+# Random string to ensure unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
@@ -0,0 +1,11 @@
+# Intelligent-Tiering Example Outputs
+
+output "bucket_name" {
+  description = "The name of the created S3 bucket"
+  value       = module.s3_bucket.bucket_id
+}
+
+output "intelligent_tiering_configurations" {
+  description = "The intelligent tiering configurations of the bucket"
+  value       = module.s3_bucket.bucket_intelligent_tiering_configurations
+}
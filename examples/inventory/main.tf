@@ -0,0 +1,103 @@
+# S3 Inventory Example
+# This example demonstrates delivering daily inventory reports to a separate audit bucket
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+data "aws_caller_identity" "current" {}
+
+locals {
+  bucket_name           = coalesce(var.bucket_name, "my-inventoried-bucket-${random_string.bucket_suffix.result}")
+  inventory_bucket_name = "${local.bucket_name}-inventory"
+}
+
+# Audit bucket receiving the inventory reports
+module "s3_inventory_bucket" {
+  source = "../../"
+
+  bucket_name = local.inventory_bucket_name
+  environment = "prod"
+  purpose     = "inventory-reports"
+
+  force_destroy = true
+
+  # Allow S3 to deliver inventory reports for the source bucket
+  bucket_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid    = "InventoryDelivery"
+        Effect = "Allow"
+        Principal = {
+          Service = "s3.amazonaws.com"
+        }
+        Action   = "s3:PutObject"
+        Resource = "arn:aws:s3:::${local.inventory_bucket_name}/*"
+        Condition = {
+          ArnLike = {
+            "aws:SourceArn" = "arn:aws:s3:::${local.bucket_name}"
+          }
+          StringEquals = {
+            "aws:SourceAccount" = data.aws_caller_identity.current.account_id
+            "s3:x-amz-acl"      = "bucket-owner-full-control"
+          }
+        }
+      }
+    ]
+  })
+
+  common_tags = {
+    Project     = "InventoryExample"
+    Owner       = "Audit"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+# Bucket being inventoried
+module "s3_bucket" {
+  source = "../../"
+
+  bucket_name = local.bucket_name
+  environment = "prod"
+  purpose     = "application-data"
+
+  force_destroy = true
+
+  inventory_configurations = {
+    daily-audit = {
+      included_object_versions = "All"
+      schedule_frequency       = "Daily"
+      destination_bucket_arn   = module.s3_inventory_bucket.bucket_arn
+      destination_prefix       = "inventory"
+      destination_format       = "CSV"
+      destination_encryption   = var.inventory_kms_key_arn != null ? { sse_kms = { key_id = var.inventory_kms_key_arn } } : null
+      optional_fields          = ["Size", "LastModifiedDate", "StorageClass", "EncryptionStatus"]
+    }
+  }
+
+  common_tags = {
+    Project     = "InventoryExample"
+    Owner       = "DevOps"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+# Random string to ensure unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
@@ -0,0 +1,26 @@
+# Inventory Example Outputs
+
+output "bucket_name" {
+  description = "The name of the inventoried bucket"
+  value       = module.s3_bucket.bucket_id
+}
+
+output "inventory_bucket_name" {
+  description = "The name of the bucket receiving inventory reports"
+  value       = module.s3_inventory_bucket.bucket_id
+}
+
+output "inventory_bucket_arn" {
+  description = "The ARN of the bucket receiving inventory reports"
+  value       = module.s3_inventory_bucket.bucket_arn
+}
+
+output "inventory_configurations" {
+  description = "The names of the inventory configurations"
+  value       = module.s3_bucket.bucket_inventory_configurations
+}
+
+output "required_destination_bucket_policies_json" {
+  description = "The policy documents the module generates for its delivery destinations, keyed by destination bucket ARN"
+  value       = module.s3_bucket.required_destination_bucket_policies_json
+}
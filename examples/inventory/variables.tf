@@ -0,0 +1,19 @@
+# Inventory Example Variables
+
+variable "region" {
+  description = "AWS region for the example resources"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "bucket_name" {
+  description = "The name of the inventoried bucket. The inventory bucket name is derived from it. A random name is generated when null"
+  type        = string
+  default     = null
+}
+
+variable "inventory_kms_key_arn" {
+  description = "KMS key ARN used to encrypt the inventory reports. The reports are not encrypted by inventory when null"
+  type        = string
+  default     = null
+}
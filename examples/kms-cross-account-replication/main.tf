@@ -0,0 +1,80 @@
+# S3 Cross-Account KMS Replication Example
+# This example demonstrates replicating an SSE-KMS bucket to a bucket and KMS key owned by another account
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+locals {
+  bucket_name = coalesce(var.bucket_name, "my-cross-account-replication-${random_string.bucket_suffix.result}")
+}
+
+# Source bucket encrypted with a module-managed key. The destination bucket and its
+# KMS key belong to the destination account and are managed there
+module "s3_source" {
+  source = "../../"
+
+  bucket_name = local.bucket_name
+  environment = "prod"
+  purpose     = "primary-storage"
+
+  force_destroy = true
+
+  encryption_algorithm = "aws:kms"
+  create_kms_key       = true
+
+  # The module creates the replication IAM role, including kms:Encrypt on the replica key
+  replication_configuration = {
+    rules = [
+      {
+        id       = "cross-account-dr"
+        status   = "Enabled"
+        priority = 1
+        filter = {
+          prefix = ""
+        }
+        destination = {
+          bucket             = var.destination_bucket_arn
+          account            = var.destination_account_id
+          storage_class      = "STANDARD"
+          replica_kms_key_id = var.replica_kms_key_arn
+          access_control_translation = {
+            owner = "Destination"
+          }
+        }
+        source_selection_criteria = {
+          sse_kms_encrypted_objects = {
+            status = "Enabled"
+          }
+        }
+        delete_marker_replication = {
+          status = "Enabled"
+        }
+      }
+    ]
+  }
+
+  common_tags = {
+    Project     = "CrossAccountReplicationExample"
+    Owner       = "DevOps"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+# Random string to ensure unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
@@ -0,0 +1,16 @@
+# Cross-Account KMS Replication Example Outputs
+
+output "source_bucket_name" {
+  description = "The name of the source bucket"
+  value       = module.s3_source.bucket_id
+}
+
+output "source_kms_key_arn" {
+  description = "The ARN of the KMS key encrypting the source bucket"
+  value       = module.s3_source.kms_key_arn
+}
+
+output "replication_role_arn" {
+  description = "The ARN of the replication role. Grant it access in the destination bucket policy and replica key policy"
+  value       = module.s3_source.bucket_replication_role_arn
+}
@@ -0,0 +1,28 @@
+# Cross-Account KMS Replication Example Variables
+
+variable "region" {
+  description = "AWS region for the source bucket"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "bucket_name" {
+  description = "The name of the source bucket. A random name is generated when null"
+  type        = string
+  default     = null
+}
+
+variable "destination_bucket_arn" {
+  description = "ARN of the versioned destination bucket in the destination account"
+  type        = string
+}
+
+variable "destination_account_id" {
+  description = "ID of the account that owns the destination bucket and replica key. Replicas are owned by this account"
+  type        = string
+}
+
+variable "replica_kms_key_arn" {
+  description = "ARN of the KMS key in the destination account used to encrypt replicas"
+  type        = string
+}
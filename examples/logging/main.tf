@@ -0,0 +1,93 @@
+# S3 Server Access Logging Example
+# This example demonstrates shipping server access logs to a central logging bucket
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+locals {
+  bucket_name     = coalesce(var.bucket_name, "my-logged-bucket-${random_string.bucket_suffix.result}")
+  log_bucket_name = "${local.bucket_name}-logs"
+}
+
+# Central logging bucket that receives the access logs
+module "s3_log_bucket" {
+  source = "../../"
+
+  bucket_name = local.log_bucket_name
+  environment = "prod"
+  purpose     = "access-logs"
+
+  force_destroy = true
+
+  # Allow the S3 logging service to deliver logs for the source bucket
+  bucket_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid    = "S3ServerAccessLogsPolicy"
+        Effect = "Allow"
+        Principal = {
+          Service = "logging.s3.amazonaws.com"
+        }
+        Action   = "s3:PutObject"
+        Resource = "arn:aws:s3:::${local.log_bucket_name}/*"
+        Condition = {
+          ArnLike = {
+            "aws:SourceArn" = "arn:aws:s3:::${local.bucket_name}"
+          }
+        }
+      }
+    ]
+  })
+
+  common_tags = {
+    Project     = "LoggingExample"
+    Owner       = "Security"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+# Bucket whose access is logged
+module "s3_bucket" {
+  source = "../../"
+
+  bucket_name = local.bucket_name
+  environment = "prod"
+  purpose     = "application-data"
+
+  force_destroy = true
+
+  logging = {
+    target_bucket = module.s3_log_bucket.bucket_id
+    target_prefix = "access-logs/${local.bucket_name}/"
+
+    target_object_key_format = var.target_object_key_format
+    partition_date_source    = var.partition_date_source
+  }
+
+  common_tags = {
+    Project     = "LoggingExample"
+    Owner       = "DevOps"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+# Random string to ensure unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
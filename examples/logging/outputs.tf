@@ -0,0 +1,21 @@
+# Logging Example Outputs
+
+output "bucket_name" {
+  description = "The name of the logged bucket"
+  value       = module.s3_bucket.bucket_id
+}
+
+output "log_bucket_name" {
+  description = "The name of the bucket receiving access logs"
+  value       = module.s3_log_bucket.bucket_id
+}
+
+output "logging_enabled" {
+  description = "Whether server access logging is enabled"
+  value       = module.s3_bucket.logging_enabled
+}
+
+output "logging_target_prefix" {
+  description = "The prefix under which access logs are delivered"
+  value       = module.s3_bucket.bucket_logging_target.target_prefix
+}
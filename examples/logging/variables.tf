@@ -0,0 +1,25 @@
+# Logging Example Variables
+
+variable "region" {
+  description = "AWS region for the example resources"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "bucket_name" {
+  description = "The name of the logged bucket. The log bucket name is derived from it. A random name is generated when null"
+  type        = string
+  default     = null
+}
+
+variable "target_object_key_format" {
+  description = "Log object key format, simple or partitioned"
+  type        = string
+  default     = "simple"
+}
+
+variable "partition_date_source" {
+  description = "Date used to partition log keys when target_object_key_format is partitioned (EventTime or DeliveryTime)"
+  type        = string
+  default     = "EventTime"
+}
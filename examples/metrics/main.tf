@@ -0,0 +1,47 @@
+# S3 Request Metrics Example
+# This example demonstrates publishing CloudWatch request metrics for a prefix
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+module "s3_bucket" {
+  source = "../../"
+
+  bucket_name = coalesce(var.bucket_name, "my-metrics-bucket-${random_string.bucket_suffix.result}")
+  environment = "prod"
+  purpose     = "api-storage"
+
+  force_destroy = true
+
+  metrics_configurations = [
+    {
+      id            = "uploads"
+      filter_prefix = "uploads/"
+    }
+  ]
+
+  common_tags = {
+    Project     = "MetricsExample"
+    Owner       = "SRE"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+# Random string to ensure unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
@@ -0,0 +1,11 @@
+# Metrics Example Outputs
+
+output "bucket_name" {
+  description = "The name of the created S3 bucket"
+  value       = module.s3_bucket.bucket_id
+}
+
+output "metrics_configuration_ids" {
+  description = "The IDs of the request metrics configurations"
+  value       = module.s3_bucket.metrics_configuration_ids
+}
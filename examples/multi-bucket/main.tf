@@ -0,0 +1,48 @@
+# S3 Multi-Bucket Example
+# This example demonstrates creating several similar buckets from a map with for_each
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+locals {
+  name_prefix = coalesce(var.name_prefix, "my-team-${random_string.bucket_suffix.result}")
+}
+
+module "s3_bucket" {
+  source   = "../../"
+  for_each = var.buckets
+
+  bucket_name = "${local.name_prefix}-${each.key}"
+  environment = "dev"
+  purpose     = each.value.purpose
+
+  force_destroy = true
+
+  versioning_status = each.value.versioning_status
+  tags              = each.value.tags
+
+  common_tags = {
+    Project     = "MultiBucketExample"
+    Owner       = "DevOps"
+    CostCenter  = "IT"
+    Environment = "Development"
+  }
+}
+
+# Random string to ensure unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
@@ -0,0 +1,16 @@
+# Multi-Bucket Example Outputs
+
+output "bucket_names" {
+  description = "Bucket names keyed by map key"
+  value       = { for key, bucket in module.s3_bucket : key => bucket.bucket_name }
+}
+
+output "bucket_arns" {
+  description = "Bucket ARNs keyed by map key"
+  value       = { for key, bucket in module.s3_bucket : key => bucket.bucket_arn }
+}
+
+output "bucket_versioning_statuses" {
+  description = "Versioning status of each bucket keyed by map key"
+  value       = { for key, bucket in module.s3_bucket : key => bucket.bucket_versioning_status }
+}
@@ -0,0 +1,37 @@
+# Multi-Bucket Example Variables
+
+variable "region" {
+  description = "AWS region for the example resources"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "name_prefix" {
+  description = "Prefix for every bucket name. Each bucket appends its map key. A random prefix is generated when null"
+  type        = string
+  default     = null
+}
+
+variable "buckets" {
+  description = "Buckets to create, keyed by the suffix appended to name_prefix"
+  type = map(object({
+    purpose           = string
+    versioning_status = optional(string, "Enabled")
+    tags              = optional(map(string), {})
+  }))
+  default = {
+    raw = {
+      purpose = "raw-data"
+      tags = {
+        DataClass = "Internal"
+      }
+    }
+    processed = {
+      purpose = "processed-data"
+    }
+    scratch = {
+      purpose           = "scratch-space"
+      versioning_status = "Suspended"
+    }
+  }
+}
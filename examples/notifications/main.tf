@@ -0,0 +1,193 @@
+# S3 Event Notifications Example
+# This example demonstrates Lambda, SQS and SNS destinations sharing the bucket's single notification configuration
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+    archive = {
+      source  = "hashicorp/archive"
+      version = ">= 2.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+data "aws_caller_identity" "current" {}
+
+data "aws_region" "current" {}
+
+locals {
+  bucket_name   = coalesce(var.bucket_name, "my-notification-bucket-${random_string.bucket_suffix.result}")
+  function_name = "${local.bucket_name}-thumbnails"
+
+  # Built from known values so the module can key its Lambda permissions at plan time
+  function_arn = "arn:aws:lambda:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:function:${local.function_name}"
+}
+
+module "s3_bucket" {
+  source = "../../"
+
+  bucket_name = local.bucket_name
+  environment = "dev"
+  purpose     = "image-uploads"
+
+  force_destroy = true
+
+  notification_configuration = {
+    lambda_functions = [
+      {
+        lambda_function_arn = local.function_arn
+        events              = ["s3:ObjectCreated:*"]
+        filter_prefix       = "uploads/"
+        filter_suffix       = ".jpg"
+      }
+    ]
+    queues = [
+      {
+        queue_arn     = aws_sqs_queue.uploads.arn
+        events        = ["s3:ObjectCreated:*"]
+        filter_prefix = "documents/"
+      }
+    ]
+    topics = [
+      {
+        topic_arn = aws_sns_topic.deletions.arn
+        events    = ["s3:ObjectRemoved:*"]
+      }
+    ]
+  }
+
+  common_tags = {
+    Project     = "NotificationsExample"
+    Owner       = "DevOps"
+    CostCenter  = "IT"
+    Environment = "Development"
+  }
+
+  # The function must exist before the module grants S3 permission to invoke it,
+  # and S3 validates that it may reach the queue and topic when the notification is created
+  depends_on = [
+    aws_lambda_function.thumbnails,
+    aws_sqs_queue_policy.uploads,
+    aws_sns_topic_policy.deletions
+  ]
+}
+
+# Random string to ensure unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
+
+# Placeholder thumbnail function
+data "archive_file" "thumbnails" {
+  type        = "zip"
+  output_path = "${path.module}/thumbnails.zip"
+
+  source {
+    filename = "index.py"
+    content  = <<EOF
+def handler(event, context):
+    return {"records": len(event.get("Records", []))}
+EOF
+  }
+}
+
+resource "aws_iam_role" "thumbnails" {
+  name_prefix = "s3-thumbnails-"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Principal = {
+          Service = "lambda.amazonaws.com"
+        }
+        Action = "sts:AssumeRole"
+      }
+    ]
+  })
+}
+
+resource "aws_iam_role_policy_attachment" "thumbnails" {
+  role       = aws_iam_role.thumbnails.name
+  policy_arn = "arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole"
+}
+
+resource "aws_lambda_function" "thumbnails" {
+  function_name    = local.function_name
+  role             = aws_iam_role.thumbnails.arn
+  runtime          = "python3.12"
+  handler          = "index.handler"
+  filename         = data.archive_file.thumbnails.output_path
+  source_code_hash = data.archive_file.thumbnails.output_base64sha256
+}
+
+# Topic receiving object deletion events. The module does not manage topic
+# policies, so the topic must allow the bucket to publish.
+resource "aws_sns_topic" "deletions" {
+  name = "${local.bucket_name}-deletions"
+}
+
+resource "aws_sns_topic_policy" "deletions" {
+  arn = aws_sns_topic.deletions.arn
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid    = "AllowS3Publish"
+        Effect = "Allow"
+        Principal = {
+          Service = "s3.amazonaws.com"
+        }
+        Action   = "SNS:Publish"
+        Resource = aws_sns_topic.deletions.arn
+        Condition = {
+          ArnLike = {
+            "aws:SourceArn" = "arn:aws:s3:::${local.bucket_name}"
+          }
+        }
+      }
+    ]
+  })
+}
+
+# Queue for asynchronous document processing. As with topics, the queue
+# policy must allow the bucket to send messages.
+resource "aws_sqs_queue" "uploads" {
+  name = "${local.bucket_name}-uploads"
+}
+
+resource "aws_sqs_queue_policy" "uploads" {
+  queue_url = aws_sqs_queue.uploads.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid    = "AllowS3SendMessage"
+        Effect = "Allow"
+        Principal = {
+          Service = "s3.amazonaws.com"
+        }
+        Action   = "sqs:SendMessage"
+        Resource = aws_sqs_queue.uploads.arn
+        Condition = {
+          ArnLike = {
+            "aws:SourceArn" = "arn:aws:s3:::${local.bucket_name}"
+          }
+        }
+      }
+    ]
+  })
+}
@@ -0,0 +1,26 @@
+# Notifications Example Outputs
+
+output "bucket_name" {
+  description = "The name of the created S3 bucket"
+  value       = module.s3_bucket.bucket_id
+}
+
+output "lambda_function_arn" {
+  description = "The ARN of the function invoked on uploads"
+  value       = aws_lambda_function.thumbnails.arn
+}
+
+output "notification_configuration" {
+  description = "The notification configuration of the bucket"
+  value       = module.s3_bucket.bucket_notification_configuration
+}
+
+output "sqs_queue_arn" {
+  description = "The ARN of the queue receiving document upload events"
+  value       = aws_sqs_queue.uploads.arn
+}
+
+output "sns_topic_arn" {
+  description = "The ARN of the topic receiving object deletion events"
+  value       = aws_sns_topic.deletions.arn
+}
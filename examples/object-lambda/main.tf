@@ -0,0 +1,117 @@
+# S3 Object Lambda Example
+# This example demonstrates transforming objects on read through an Object Lambda access point
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+    archive = {
+      source  = "hashicorp/archive"
+      version = ">= 2.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+locals {
+  bucket_name = coalesce(var.bucket_name, "my-object-lambda-bucket-${random_string.bucket_suffix.result}")
+}
+
+module "s3_bucket" {
+  source = "../../"
+
+  bucket_name = local.bucket_name
+  environment = "prod"
+  purpose     = "customer-records"
+
+  force_destroy = true
+
+  # The supporting access point is created by the module because it is not declared in access_points
+  object_lambda_access_points = {
+    (var.object_lambda_access_point_name) = {
+      lambda_function_arn = aws_lambda_function.transform.arn
+    }
+  }
+
+  common_tags = {
+    Project     = "ObjectLambdaExample"
+    Owner       = "DataProtection"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+# Random string to ensure unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
+
+# Placeholder transform that returns objects unchanged. Replace the body with PII redaction.
+data "archive_file" "transform" {
+  type        = "zip"
+  output_path = "${path.module}/transform.zip"
+
+  source {
+    filename = "index.py"
+    content  = <<EOF
+import urllib.request
+
+import boto3
+
+s3 = boto3.client("s3")
+
+
+def handler(event, context):
+    request = event["getObjectContext"]
+    with urllib.request.urlopen(request["inputS3Url"]) as response:
+        body = response.read()
+
+    s3.write_get_object_response(
+        Body=body,
+        RequestRoute=request["outputRoute"],
+        RequestToken=request["outputToken"],
+    )
+    return {"status_code": 200}
+EOF
+  }
+}
+
+resource "aws_iam_role" "transform" {
+  name_prefix = "s3-object-lambda-"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Principal = {
+          Service = "lambda.amazonaws.com"
+        }
+        Action = "sts:AssumeRole"
+      }
+    ]
+  })
+}
+
+# Grants logging and s3-object-lambda:WriteGetObjectResponse
+resource "aws_iam_role_policy_attachment" "transform" {
+  role       = aws_iam_role.transform.name
+  policy_arn = "arn:aws:iam::aws:policy/service-role/AmazonS3ObjectLambdaExecutionRolePolicy"
+}
+
+resource "aws_lambda_function" "transform" {
+  function_name    = "${local.bucket_name}-transform"
+  role             = aws_iam_role.transform.arn
+  runtime          = "python3.12"
+  handler          = "index.handler"
+  filename         = data.archive_file.transform.output_path
+  source_code_hash = data.archive_file.transform.output_base64sha256
+}
@@ -0,0 +1,21 @@
+# Object Lambda Example Outputs
+
+output "bucket_name" {
+  description = "The name of the created S3 bucket"
+  value       = module.s3_bucket.bucket_id
+}
+
+output "access_point_arns" {
+  description = "The ARNs of the access points, including the supporting access point"
+  value       = module.s3_bucket.access_point_arns
+}
+
+output "object_lambda_access_point_arns" {
+  description = "The ARNs of the Object Lambda access points, keyed by name"
+  value       = module.s3_bucket.object_lambda_access_point_arns
+}
+
+output "transform_function_arn" {
+  description = "The ARN of the transform Lambda function"
+  value       = aws_lambda_function.transform.arn
+}
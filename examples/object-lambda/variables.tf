@@ -0,0 +1,19 @@
+# Object Lambda Example Variables
+
+variable "region" {
+  description = "AWS region for the example resources"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "bucket_name" {
+  description = "The name of the S3 bucket. A random name is generated when null"
+  type        = string
+  default     = null
+}
+
+variable "object_lambda_access_point_name" {
+  description = "Name of the Object Lambda access point. Must be unique in the account and region"
+  type        = string
+  default     = "redact-pii"
+}
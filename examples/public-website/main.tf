@@ -0,0 +1,95 @@
+# S3 Public Website Example
+# This example demonstrates a complete public-read static website, granted through a bucket policy or ACLs
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+locals {
+  bucket_name = coalesce(var.bucket_name, "my-public-website-${random_string.bucket_suffix.result}")
+  use_acls    = var.public_read_method == "acl"
+}
+
+module "s3_website" {
+  source = "../../"
+
+  bucket_name = local.bucket_name
+  environment = "prod"
+  purpose     = "public-website"
+
+  force_destroy = true
+
+  website_configuration = {
+    index_document = "index.html"
+    error_document = "error.html"
+  }
+
+  # Only the public access block flags for the chosen method are relaxed
+  block_public_acls       = !local.use_acls
+  ignore_public_acls      = !local.use_acls
+  block_public_policy     = local.use_acls
+  restrict_public_buckets = local.use_acls
+
+  # ACLs need an ownership setting that keeps them enabled
+  object_ownership = local.use_acls ? "BucketOwnerPreferred" : "BucketOwnerEnforced"
+  acl              = local.use_acls ? "public-read" : null
+
+  bucket_policy = local.use_acls ? null : jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid       = "PublicReadGetObject"
+        Effect    = "Allow"
+        Principal = "*"
+        Action    = "s3:GetObject"
+        Resource  = "arn:aws:s3:::${local.bucket_name}/*"
+      }
+    ]
+  })
+
+  common_tags = {
+    Project     = "PublicWebsiteExample"
+    Owner       = "Marketing"
+    CostCenter  = "Marketing"
+    Environment = "Production"
+  }
+}
+
+# Random string to ensure unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
+
+# Website documents. With ACLs each object must be public-read itself
+resource "aws_s3_object" "index_html" {
+  bucket       = module.s3_website.bucket_id
+  key          = "index.html"
+  content      = var.index_content
+  content_type = "text/html"
+  acl          = local.use_acls ? "public-read" : null
+
+  # Ownership controls and the public access block must be in place before a public-read ACL is accepted
+  depends_on = [module.s3_website]
+}
+
+resource "aws_s3_object" "error_html" {
+  bucket       = module.s3_website.bucket_id
+  key          = "error.html"
+  content      = "<!DOCTYPE html><html><body><h1>404 - Page Not Found</h1></body></html>"
+  content_type = "text/html"
+  acl          = local.use_acls ? "public-read" : null
+
+  depends_on = [module.s3_website]
+}
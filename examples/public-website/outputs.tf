@@ -0,0 +1,16 @@
+# Public Website Example Outputs
+
+output "bucket_name" {
+  description = "The name of the website bucket"
+  value       = module.s3_website.bucket_id
+}
+
+output "website_endpoint" {
+  description = "The website endpoint"
+  value       = module.s3_website.bucket_website_endpoint
+}
+
+output "website_url" {
+  description = "The complete website URL"
+  value       = "http://${module.s3_website.bucket_website_endpoint}"
+}
@@ -0,0 +1,30 @@
+# Public Website Example Variables
+
+variable "region" {
+  description = "AWS region for the example resources"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "bucket_name" {
+  description = "The name of the website bucket. A random name is generated when null"
+  type        = string
+  default     = null
+}
+
+variable "public_read_method" {
+  description = "How objects are made public: a public-read bucket policy (policy) or public-read ACLs (acl)"
+  type        = string
+  default     = "policy"
+
+  validation {
+    condition     = contains(["policy", "acl"], var.public_read_method)
+    error_message = "public_read_method must be either 'policy' or 'acl'."
+  }
+}
+
+variable "index_content" {
+  description = "HTML served as the index document"
+  type        = string
+  default     = "<!DOCTYPE html><html><body><h1>Hello from S3!</h1></body></html>"
+}
@@ -0,0 +1,108 @@
+# S3 Encrypted Replication Example
+# This example demonstrates SSE-KMS replication with Replication Time Control to a disaster recovery region
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+provider "aws" {
+  alias  = "replica"
+  region = var.replica_region
+}
+
+locals {
+  bucket_name = coalesce(var.bucket_name, "my-encrypted-replication-${random_string.bucket_suffix.result}")
+}
+
+# Destination bucket encrypted with its own key in the disaster recovery region
+module "s3_replica" {
+  source = "../../"
+
+  providers = {
+    aws = aws.replica
+  }
+
+  bucket_name = "${local.bucket_name}-replica"
+  environment = "prod"
+  purpose     = "disaster-recovery"
+
+  force_destroy = true
+
+  encryption_algorithm = "aws:kms"
+  create_kms_key       = true
+
+  # The source replication role writes replicas encrypted with this key
+  kms_key_user_principals = [module.s3_source.bucket_replication_role_arn]
+
+  common_tags = {
+    Project     = "EncryptedReplicationExample"
+    Owner       = "DevOps"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+# Source bucket replicating KMS-encrypted objects within 15 minutes
+module "s3_source" {
+  source = "../../"
+
+  bucket_name = local.bucket_name
+  environment = "prod"
+  purpose     = "primary-storage"
+
+  force_destroy = true
+
+  encryption_algorithm = "aws:kms"
+  create_kms_key       = true
+
+  # Alarm when replicas fall behind the Replication Time Control threshold
+  create_replication_latency_alarm      = var.create_replication_latency_alarm
+  replication_latency_threshold_seconds = var.replication_latency_threshold_seconds
+
+  replication_configuration = {
+    rules = [
+      {
+        id     = "encrypted-disaster-recovery"
+        status = "Enabled"
+        destination = {
+          bucket             = module.s3_replica.bucket_arn
+          replica_kms_key_id = var.encrypt_replicas ? module.s3_replica.kms_key_arn : null
+
+          # Metrics are enabled automatically alongside Replication Time Control
+          replication_time = {
+            status  = "Enabled"
+            minutes = 15
+          }
+        }
+        # SSE-KMS objects are selected automatically because the source bucket uses aws:kms
+        delete_marker_replication = {
+          status = "Enabled"
+        }
+      }
+    ]
+  }
+
+  common_tags = {
+    Project     = "EncryptedReplicationExample"
+    Owner       = "DevOps"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+# Random string to ensure unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
@@ -0,0 +1,26 @@
+# Encrypted Replication Example Outputs
+
+output "source_bucket_name" {
+  description = "The name of the source bucket"
+  value       = module.s3_source.bucket_id
+}
+
+output "replica_bucket_name" {
+  description = "The name of the replica bucket"
+  value       = module.s3_replica.bucket_id
+}
+
+output "replica_kms_key_arn" {
+  description = "The ARN of the KMS key encrypting replicas"
+  value       = module.s3_replica.kms_key_arn
+}
+
+output "replication_role_arn" {
+  description = "The ARN of the role replicating objects into the replica bucket"
+  value       = module.s3_source.bucket_replication_role_arn
+}
+
+output "replication_latency_alarm_arns" {
+  description = "The ARNs of the replication latency alarms, keyed by replication rule id"
+  value       = module.s3_source.replication_latency_alarm_arns
+}
@@ -0,0 +1,37 @@
+# Encrypted Replication Example Variables
+
+variable "region" {
+  description = "AWS region for the example resources"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "replica_region" {
+  description = "AWS region for the replica bucket. Must differ from region for cross-region replication"
+  type        = string
+  default     = "us-west-2"
+}
+
+variable "bucket_name" {
+  description = "The name of the source bucket. The replica bucket name is derived from it. A random name is generated when null"
+  type        = string
+  default     = null
+}
+
+variable "encrypt_replicas" {
+  description = "Whether replicas are encrypted with the replica bucket KMS key. The module rejects an SSE-KMS source without one"
+  type        = bool
+  default     = true
+}
+
+variable "create_replication_latency_alarm" {
+  description = "Whether to create a CloudWatch alarm on the replication latency of the disaster recovery rule"
+  type        = bool
+  default     = false
+}
+
+variable "replication_latency_threshold_seconds" {
+  description = "Replication latency in seconds above which the alarm fires"
+  type        = number
+  default     = 900
+}
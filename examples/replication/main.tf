@@ -0,0 +1,99 @@
+# S3 Cross-Region Replication Example
+# This example demonstrates replicating a primary bucket in us-east-1 to a disaster recovery bucket in us-west-2
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+provider "aws" {
+  alias  = "replica"
+  region = var.replica_region
+}
+
+locals {
+  bucket_name = coalesce(var.bucket_name, "my-replication-bucket-${random_string.bucket_suffix.result}")
+}
+
+# Destination bucket in the disaster recovery region
+module "s3_replica" {
+  source = "../../"
+
+  providers = {
+    aws = aws.replica
+  }
+
+  bucket_name = "${local.bucket_name}-replica"
+  environment = "prod"
+  purpose     = "disaster-recovery"
+
+  force_destroy = true
+
+  # Acceleration needs a bucket name without dots, which the derived replica name keeps
+  acceleration_status = var.replica_acceleration_status
+
+  common_tags = {
+    Project     = "ReplicationExample"
+    Owner       = "DevOps"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+# Source bucket replicating to the destination bucket
+module "s3_source" {
+  source = "../../"
+
+  bucket_name = local.bucket_name
+  environment = "prod"
+  purpose     = "primary-storage"
+
+  force_destroy = true
+
+  # The module creates the replication IAM role when no role is provided
+  replication_role_arn = var.replication_role_arn
+
+  replication_configuration = {
+    rules = [
+      {
+        id       = "disaster-recovery"
+        status   = "Enabled"
+        priority = 1
+        filter = {
+          prefix = ""
+          tags   = [for key, value in var.replicate_tags : { key = key, value = value }]
+        }
+        destination = {
+          bucket        = module.s3_replica.bucket_arn
+          storage_class = "STANDARD"
+        }
+        delete_marker_replication = {
+          status = "Enabled"
+        }
+      }
+    ]
+  }
+
+  common_tags = {
+    Project     = "ReplicationExample"
+    Owner       = "DevOps"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+# Random string to ensure unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
@@ -0,0 +1,41 @@
+# Replication Example Outputs
+
+output "source_bucket_name" {
+  description = "The name of the source bucket"
+  value       = module.s3_source.bucket_id
+}
+
+output "source_bucket_arn" {
+  description = "The ARN of the source bucket"
+  value       = module.s3_source.bucket_arn
+}
+
+output "replica_bucket_name" {
+  description = "The name of the replica bucket"
+  value       = module.s3_replica.bucket_id
+}
+
+output "replica_bucket_arn" {
+  description = "The ARN of the replica bucket"
+  value       = module.s3_replica.bucket_arn
+}
+
+output "replication_role_arn" {
+  description = "The ARN of the IAM role used for replication"
+  value       = module.s3_source.bucket_replication_role_arn
+}
+
+output "replication_configuration" {
+  description = "The replication configuration of the source bucket"
+  value       = module.s3_source.bucket_replication_configuration
+}
+
+output "replica_acceleration_status" {
+  description = "The transfer acceleration status of the replica bucket"
+  value       = module.s3_replica.acceleration_status
+}
+
+output "replica_acceleration_endpoint" {
+  description = "The transfer acceleration endpoint of the replica bucket"
+  value       = module.s3_replica.acceleration_endpoint
+}
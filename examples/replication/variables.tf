@@ -0,0 +1,37 @@
+# Replication Example Variables
+
+variable "region" {
+  description = "AWS region for the example resources"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "replica_region" {
+  description = "AWS region for the replica bucket. Must differ from region for cross-region replication"
+  type        = string
+  default     = "us-west-2"
+}
+
+variable "bucket_name" {
+  description = "The name of the source bucket. The replica bucket name is derived from it. A random name is generated when null"
+  type        = string
+  default     = null
+}
+
+variable "replicate_tags" {
+  description = "Only objects carrying all of these tags are replicated. Leave empty to replicate every object"
+  type        = map(string)
+  default     = {}
+}
+
+variable "replication_role_arn" {
+  description = "ARN of an existing replication IAM role. The module creates one when null"
+  type        = string
+  default     = null
+}
+
+variable "replica_acceleration_status" {
+  description = "Transfer acceleration status for the replica bucket (Enabled or Suspended). Leave null to skip it"
+  type        = string
+  default     = null
+}
@@ -0,0 +1,51 @@
+# S3 Request Alarms Example
+# This example demonstrates CloudWatch alarms on the 4xx and 5xx request metrics of a bucket
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+module "s3_bucket" {
+  source = "../../"
+
+  bucket_name = coalesce(var.bucket_name, "my-alarmed-bucket-${random_string.bucket_suffix.result}")
+  environment = "prod"
+  purpose     = "api-storage"
+
+  force_destroy = true
+
+  # Alarms are created for every request metrics configuration
+  metrics_configurations = [
+    {
+      id = "EntireBucket"
+    }
+  ]
+
+  create_request_alarms = true
+  alarm_4xx_threshold   = var.alarm_4xx_threshold
+  alarm_5xx_threshold   = var.alarm_5xx_threshold
+
+  common_tags = {
+    Project     = "RequestAlarmsExample"
+    Owner       = "SRE"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+# Random string to ensure unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
@@ -0,0 +1,11 @@
+# Request Alarms Example Outputs
+
+output "bucket_name" {
+  description = "The name of the created S3 bucket"
+  value       = module.s3_bucket.bucket_id
+}
+
+output "request_alarm_arns" {
+  description = "The ARNs of the request error alarms"
+  value       = module.s3_bucket.request_alarm_arns
+}
@@ -0,0 +1,25 @@
+# Request Alarms Example Variables
+
+variable "region" {
+  description = "AWS region for the example resources"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "bucket_name" {
+  description = "The name of the S3 bucket. A random name is generated when null"
+  type        = string
+  default     = null
+}
+
+variable "alarm_4xx_threshold" {
+  description = "4xx errors per five minutes above which the alarm fires"
+  type        = number
+  default     = 100
+}
+
+variable "alarm_5xx_threshold" {
+  description = "5xx errors per five minutes above which the alarm fires"
+  type        = number
+  default     = 10
+}
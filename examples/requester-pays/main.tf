@@ -0,0 +1,42 @@
+# S3 Requester Pays Example
+# This example demonstrates distributing a public dataset where downloaders pay for transfer
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+module "s3_bucket" {
+  source = "../../"
+
+  bucket_name = coalesce(var.bucket_name, "my-dataset-bucket-${random_string.bucket_suffix.result}")
+  environment = "prod"
+  purpose     = "dataset-distribution"
+
+  force_destroy = true
+
+  request_payer = "Requester"
+
+  common_tags = {
+    Project     = "RequesterPaysExample"
+    Owner       = "Data Engineering"
+    CostCenter  = "Research"
+    Environment = "Production"
+  }
+}
+
+# Random string to ensure unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
@@ -0,0 +1,11 @@
+# Requester Pays Example Outputs
+
+output "bucket_name" {
+  description = "The name of the created S3 bucket"
+  value       = module.s3_bucket.bucket_id
+}
+
+output "request_payer" {
+  description = "Who pays for requests and data transfer"
+  value       = module.s3_bucket.request_payer
+}
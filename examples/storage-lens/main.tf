@@ -0,0 +1,53 @@
+# S3 Storage Lens Example
+# This example demonstrates a Storage Lens dashboard scoped to a single bucket
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+locals {
+  bucket_name = coalesce(var.bucket_name, "my-lens-bucket-${random_string.bucket_suffix.result}")
+}
+
+module "s3_bucket" {
+  source = "../../"
+
+  bucket_name = local.bucket_name
+  environment = "prod"
+  purpose     = "finops-insights"
+
+  force_destroy = true
+
+  create_storage_lens_configuration = true
+  storage_lens = {
+    id = "${local.bucket_name}-lens"
+    account_level = {
+      activity_metrics_enabled              = var.activity_metrics_enabled
+      bucket_level_activity_metrics_enabled = var.activity_metrics_enabled
+    }
+  }
+
+  common_tags = {
+    Project     = "StorageLensExample"
+    Owner       = "FinOps"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+# Random string to ensure unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
@@ -0,0 +1,16 @@
+# Storage Lens Example Outputs
+
+output "bucket_name" {
+  description = "The name of the created S3 bucket"
+  value       = module.s3_bucket.bucket_id
+}
+
+output "bucket_arn" {
+  description = "The ARN of the created S3 bucket"
+  value       = module.s3_bucket.bucket_arn
+}
+
+output "storage_lens_configuration_arn" {
+  description = "The ARN of the Storage Lens configuration"
+  value       = module.s3_bucket.storage_lens_configuration_arn
+}
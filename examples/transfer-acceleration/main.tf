@@ -0,0 +1,43 @@
+# S3 Transfer Acceleration Example
+# This example demonstrates speeding up long-distance uploads through CloudFront edge locations
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+module "s3_bucket" {
+  source = "../../"
+
+  # Transfer acceleration requires a bucket name without dots
+  bucket_name = coalesce(var.bucket_name, "my-accelerated-bucket-${random_string.bucket_suffix.result}")
+  environment = "prod"
+  purpose     = "global-uploads"
+
+  force_destroy = true
+
+  acceleration_status = var.acceleration_status
+
+  common_tags = {
+    Project     = "TransferAccelerationExample"
+    Owner       = "DevOps"
+    CostCenter  = "IT"
+    Environment = "Production"
+  }
+}
+
+# Random string to ensure unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
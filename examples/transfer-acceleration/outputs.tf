@@ -0,0 +1,16 @@
+# Transfer Acceleration Example Outputs
+
+output "bucket_name" {
+  description = "The name of the created S3 bucket"
+  value       = module.s3_bucket.bucket_id
+}
+
+output "acceleration_endpoint" {
+  description = "The transfer acceleration endpoint of the bucket"
+  value       = module.s3_bucket.acceleration_endpoint
+}
+
+output "acceleration_status" {
+  description = "The transfer acceleration status of the bucket"
+  value       = module.s3_bucket.acceleration_status
+}
@@ -0,0 +1,19 @@
+# Transfer Acceleration Example Variables
+
+variable "region" {
+  description = "AWS region for the example resources"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "bucket_name" {
+  description = "The name of the S3 bucket. A random name is generated when null"
+  type        = string
+  default     = null
+}
+
+variable "acceleration_status" {
+  description = "Transfer acceleration status for the bucket"
+  type        = string
+  default     = "Enabled"
+}
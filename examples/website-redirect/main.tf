@@ -0,0 +1,48 @@
+# S3 Website Redirect Example
+# This example demonstrates an apex bucket that redirects every request to the www host
+
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+module "s3_apex_redirect" {
+  source = "../../"
+
+  bucket_name = coalesce(var.bucket_name, "my-apex-bucket-${random_string.bucket_suffix.result}")
+  environment = "prod"
+  purpose     = "website-redirect"
+
+  force_destroy = true
+
+  # Redirect all requests instead of serving index/error documents
+  website_configuration = {
+    redirect_all_requests_to = {
+      host_name = var.redirect_host_name
+      protocol  = "https"
+    }
+  }
+
+  common_tags = {
+    Project     = "WebsiteRedirectExample"
+    Owner       = "DevOps"
+    CostCenter  = "Marketing"
+    Environment = "Production"
+  }
+}
+
+# Random string to ensure unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
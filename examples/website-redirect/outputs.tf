@@ -0,0 +1,11 @@
+# Website Redirect Example Outputs
+
+output "bucket_name" {
+  description = "The name of the apex bucket"
+  value       = module.s3_apex_redirect.bucket_id
+}
+
+output "website_endpoint" {
+  description = "The website endpoint that issues the redirects"
+  value       = module.s3_apex_redirect.bucket_website_endpoint
+}
@@ -0,0 +1,19 @@
+# Website Redirect Example Variables
+
+variable "region" {
+  description = "AWS region for the example resources"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "bucket_name" {
+  description = "The name of the apex bucket. A random name is generated when null"
+  type        = string
+  default     = null
+}
+
+variable "redirect_host_name" {
+  description = "Host name that every request to the apex bucket is redirected to"
+  type        = string
+  default     = "www.example.com"
+}
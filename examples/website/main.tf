@@ -12,20 +12,38 @@ terraform {
 }
 
 provider "aws" {
-  region = "us-east-1"
+  region = var.region
+}
+
+locals {
+  bucket_name = coalesce(var.bucket_name, "my-website-bucket-${random_string.bucket_suffix.result}")
 }
 
 module "s3_website" {
   source = "../../"
 
-  bucket_name = "my-website-bucket-${random_string.bucket_suffix.result}"
+  bucket_name = local.bucket_name
   environment = "prod"
   purpose     = "website-hosting"
 
+  force_destroy = true
+
   # Website Configuration
+  website_index_document = var.index_document
+  website_error_document = var.error_document
+
   website_configuration = {
-    index_document = "index.html"
-    error_document = "error.html"
+    # Redirect pages that moved from old/ to new/
+    routing_rules = [
+      {
+        condition = {
+          key_prefix_equals = "old/"
+        }
+        redirect = {
+          replace_key_prefix_with = "new/"
+        }
+      }
+    ]
   }
 
   # CORS Configuration for web applications
@@ -70,16 +88,16 @@ module "s3_website" {
         Effect    = "Allow"
         Principal = "*"
         Action    = "s3:GetObject"
-        Resource  = "arn:aws:s3:::my-website-bucket-${random_string.bucket_suffix.result}/*"
+        Resource  = "arn:aws:s3:::${local.bucket_name}/*"
       }
     ]
   })
 
   # Override public access settings for website hosting
-  block_public_acls       = false
-  block_public_policy     = false
-  ignore_public_acls      = false
-  restrict_public_buckets = false
+  block_public_acls       = var.block_public_acls
+  block_public_policy     = var.block_public_policy
+  ignore_public_acls      = var.ignore_public_acls
+  restrict_public_buckets = var.restrict_public_buckets
 
   common_tags = {
     Project     = "WebsiteExample"
@@ -100,7 +118,7 @@ resource "random_string" "bucket_suffix" {
 # Sample website files
 resource "aws_s3_object" "index_html" {
   bucket       = module.s3_website.bucket_id
-  key          = "index.html"
+  key          = var.index_document
   content      = <<EOF
 <!DOCTYPE html>
 <html>
@@ -116,9 +134,12 @@ EOF
   content_type = "text/html"
 }
 
+# Single-page apps serve the index as the error document, so no separate page is uploaded
 resource "aws_s3_object" "error_html" {
+  count = var.error_document != var.index_document ? 1 : 0
+
   bucket       = module.s3_website.bucket_id
-  key          = "error.html"
+  key          = var.error_document
   content      = <<EOF
 <!DOCTYPE html>
 <html>
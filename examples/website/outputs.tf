@@ -10,6 +10,11 @@ output "website_domain" {
   value       = module.s3_website.bucket_website_domain
 }
 
+output "website_hosted_zone_id" {
+  description = "The hosted zone ID for Route 53 alias records pointing at the website"
+  value       = module.s3_website.website_hosted_zone_id
+}
+
 output "bucket_name" {
   description = "The name of the website bucket"
   value       = module.s3_website.bucket_id
@@ -23,4 +28,9 @@ output "bucket_arn" {
 output "website_url" {
   description = "The complete website URL"
   value       = "http://${module.s3_website.bucket_website_endpoint}"
-} 
\ No newline at end of file
+}
+
+output "public_access_block_configuration" {
+  description = "The public access block flags applied to the website bucket"
+  value       = module.s3_website.bucket_public_access_block_configuration
+}
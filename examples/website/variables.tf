@@ -0,0 +1,49 @@
+# Website Example Variables
+
+variable "region" {
+  description = "AWS region for the example resources"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "bucket_name" {
+  description = "The name of the website bucket. A random name is generated when null"
+  type        = string
+  default     = null
+}
+
+variable "index_document" {
+  description = "The website index document"
+  type        = string
+  default     = "index.html"
+}
+
+variable "error_document" {
+  description = "The website error document. Use the index document for single-page app routing"
+  type        = string
+  default     = "error.html"
+}
+
+variable "block_public_acls" {
+  description = "Whether Amazon S3 should block public ACLs for the website bucket"
+  type        = bool
+  default     = false
+}
+
+variable "block_public_policy" {
+  description = "Whether Amazon S3 should block public bucket policies. Must be false for the public read policy to apply"
+  type        = bool
+  default     = false
+}
+
+variable "ignore_public_acls" {
+  description = "Whether Amazon S3 should ignore public ACLs for the website bucket"
+  type        = bool
+  default     = false
+}
+
+variable "restrict_public_buckets" {
+  description = "Whether Amazon S3 should restrict public bucket policies. Must be false for anonymous website access"
+  type        = bool
+  default     = false
+}
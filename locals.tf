@@ -1,22 +1,278 @@
 # Local values for S3 Bucket Module
 
 locals {
-  # Common tags with computed values
+  # Default tags with computed values, overridden by per-bucket tags. common_tags is the deprecated name for default_tags
   computed_tags = merge(
     var.common_tags,
+    var.default_tags,
     {
-      Name        = var.bucket_name
+      Name        = var.bucket_name != null ? var.bucket_name : var.bucket_prefix
       Environment = var.environment
       Purpose     = var.purpose
       ManagedBy   = "Terraform"
       Module      = "terraform-aws-s3"
-    }
+    },
+    var.tags
   )
 
+  # Security profile defaults. Variables left null fall back to the selected profile
+  security_profile = {
+    none     = { enforce_ssl = false, min_tls_version = null, encryption_algorithm = "AES256", create_kms_key = false, object_lock_enabled = false }
+    baseline = { enforce_ssl = true, min_tls_version = null, encryption_algorithm = "AES256", create_kms_key = false, object_lock_enabled = false }
+    strict   = { enforce_ssl = true, min_tls_version = "1.2", encryption_algorithm = "aws:kms", create_kms_key = true, object_lock_enabled = true }
+  }[var.security_profile]
+
+  enforce_ssl             = coalesce(var.enforce_ssl, local.security_profile.enforce_ssl)
+  enforce_min_tls_version = var.enforce_min_tls_version != null ? var.enforce_min_tls_version : local.security_profile.min_tls_version
+
+  # Effective encryption settings. The encryption object takes the place of the individual variables.
+  # kms_key_id is the deprecated name of kms_key_arn and only applies when kms_key_arn is unset
+  kms_key_input = try(coalesce(var.kms_key_arn, var.kms_key_id), null)
+
+  encryption = var.encryption != null ? var.encryption : {
+    sse_algorithm      = coalesce(var.encryption_algorithm, local.security_profile.encryption_algorithm)
+    kms_master_key_id  = var.kms_key_alias != null ? try(data.aws_kms_key.lookup[0].arn, null) : local.kms_key_input
+    bucket_key_enabled = var.bucket_key_enabled
+  }
+
+  # Whether a key was supplied, decided from the inputs alone so it is known before the alias lookup runs
+  kms_key_supplied = var.encryption != null ? try(var.encryption.kms_master_key_id, null) != null : local.kms_key_input != null || var.kms_key_alias != null
+
+  # The profile only creates a key when SSE-KMS is in effect and no key was supplied
+  kms_key_requested = coalesce(var.create_kms_key, local.security_profile.create_kms_key && local.encryption.sse_algorithm == "aws:kms" && !local.kms_key_supplied)
+
   # Validation helpers
-  is_kms_encryption = var.encryption_algorithm == "aws:kms"
-  requires_kms_key  = local.is_kms_encryption && var.kms_key_id == null
+  is_kms_encryption = local.encryption.sse_algorithm == "aws:kms"
+  requires_kms_key  = local.is_kms_encryption && !local.kms_key_requested && !local.kms_key_supplied
+
+  # KMS helpers
+  create_kms_key = var.create && local.kms_key_requested
+  kms_key_arn    = local.create_kms_key ? aws_kms_key.this[0].arn : local.encryption.kms_master_key_id
+
+  # Object lock can only be enabled at creation, so a retention rule enables it too
+  object_lock_enabled = coalesce(var.object_lock_enabled, local.security_profile.object_lock_enabled) || var.object_lock_configuration != null
+
+  # Website helpers. Missing document names fall back to the website_* variables unless every request is redirected
+  website_redirects_all  = try(var.website_configuration.redirect_all_requests_to, null) != null
+  website_index_document = local.website_redirects_all ? null : try(coalesce(var.website_configuration.index_document, var.website_index_document), null)
+  website_error_document = local.website_redirects_all ? null : try(coalesce(var.website_configuration.error_document, var.website_error_document), null)
+
+  # Policy helpers
+  attach_policy = var.create && (var.bucket_policy != null || local.enforce_ssl || local.enforce_min_tls_version != null || length(var.cloudfront_oai_iam_arns) > 0 || length(var.cloudfront_distribution_arns) > 0 || length(var.restrict_to_vpc_endpoints) > 0 || length(var.cross_account_read_principals) > 0 || length(var.object_lock_governance_bypass_principals) > 0 || var.deny_unencrypted_uploads)
+
+  # Lifecycle helpers. Rules without an id are named after a hash of their content, so the id is stable across plans.
+  # abort_incomplete_multipart_upload_days appends its own whole-bucket rule after the explicit ones
+  lifecycle_rules = concat(
+    [
+      for rule in var.lifecycle_rules : merge(rule, {
+        id = rule.id != null ? rule.id : "rule-${substr(sha1(jsonencode(rule)), 0, 8)}"
+      })
+    ],
+    var.abort_incomplete_multipart_upload_days != null ? [{
+      id                                     = "abort-incomplete-multipart-upload"
+      status                                 = "Enabled"
+      filter                                 = null
+      transitions                            = null
+      expiration                             = null
+      noncurrent_version_transitions         = null
+      noncurrent_version_expiration          = null
+      abort_incomplete_multipart_upload_days = var.abort_incomplete_multipart_upload_days
+    }] : []
+  )
+
+  # Explicit rules that already abort multipart uploads across the whole bucket would overlap the rule
+  # generated from abort_incomplete_multipart_upload_days
+  lifecycle_rules_overlapping_abort = var.abort_incomplete_multipart_upload_days == null ? [] : [
+    for rule in local.lifecycle_rules : rule.id
+    if rule.id != "abort-incomplete-multipart-upload" && rule.abort_incomplete_multipart_upload_days != null && try(rule.filter.prefix, null) == null && try(rule.filter.object_size_greater_than, null) == null && try(rule.filter.object_size_less_than, null) == null
+  ]
+
+  # A filter with more than one condition, counting the prefix, each tag and each size bound, must be rendered in an and block
+  lifecycle_and_filters = {
+    for rule in local.lifecycle_rules : rule.id => (
+      (try(rule.filter.prefix, null) != null ? 1 : 0) +
+      length(try(rule.filter.tags, null) != null ? rule.filter.tags : []) +
+      (try(rule.filter.object_size_greater_than, null) != null ? 1 : 0) +
+      (try(rule.filter.object_size_less_than, null) != null ? 1 : 0) > 1
+    )
+  }
+
+  # Sids of the generated policy statements, in document order. Custom statements may not reuse them,
+  # because the generated statement would silently replace the custom one
+  generated_policy_sids = compact([
+    local.enforce_ssl ? "DenyInsecureTransport" : "",
+    local.enforce_min_tls_version != null ? "DenyOutdatedTLS" : "",
+    var.deny_unencrypted_uploads ? "DenyUnencryptedUploads" : "",
+    var.required_upload_sse_algorithm != null ? "DenyIncorrectEncryptionHeader" : "",
+    var.required_upload_kms_key_arn != null ? "DenyIncorrectKMSKey" : "",
+    length(var.cloudfront_oai_iam_arns) > 0 ? "AllowCloudFrontOAIRead" : "",
+    length(var.cloudfront_distribution_arns) > 0 ? "AllowCloudFrontOACRead" : "",
+    length(var.cross_account_read_principals) > 0 ? "AllowCrossAccountRead" : "",
+    length(var.object_lock_governance_bypass_principals) > 0 ? "AllowGovernanceRetentionBypass" : "",
+    length(var.restrict_to_vpc_endpoints) > 0 ? "DenyAccessOutsideVPCEndpoints" : "",
+  ])
+  custom_policy_statements = var.bucket_policy != null ? try(flatten([jsondecode(var.bucket_policy).Statement]), []) : []
+  custom_policy_sids       = compact([for statement in local.custom_policy_statements : try(statement.Sid, "")])
+
+  # Public access helpers. Unconditional Allow statements for any principal and public canned ACLs are what the public access block rejects
+  public_policy_requested = anytrue([
+    for statement in local.custom_policy_statements : try(statement.Effect, "") == "Allow" && try(statement.Condition, null) == null && (
+      try(statement.Principal == "*", false) || contains(flatten([try(statement.Principal.AWS, [])]), "*")
+    )
+  ])
+  public_acl_requested = try(contains(["public-read", "public-read-write", "authenticated-read"], var.acl), false) || anytrue([
+    for grant in var.grants : contains(["http://acs.amazonaws.com/groups/global/AllUsers", "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"], coalesce(grant.uri, "none"))
+  ])
+
+  # Replication helpers
+  replication_enabled     = var.create && var.replication_configuration != null && length(try(var.replication_configuration.rules, [])) > 0
+  replication_role_input  = try(coalesce(var.replication_configuration.role, var.replication_role_arn), null)
+  create_replication_role = local.replication_enabled && local.replication_role_input == null
+  replication_role_arn    = local.create_replication_role ? aws_iam_role.replication[0].arn : local.replication_role_input
+
+  replication_destination_object_arns = local.replication_enabled ? distinct([
+    for rule in var.replication_configuration.rules : "${rule.destination.bucket}/*"
+  ]) : []
+  replication_owner_override_object_arns = local.replication_enabled ? distinct([
+    for rule in var.replication_configuration.rules : "${rule.destination.bucket}/*" if rule.destination.access_control_translation != null
+  ]) : []
+
+  replication_filters = local.replication_enabled ? {
+    for rule in var.replication_configuration.rules : rule.id => {
+      prefix = try(rule.filter.prefix, "") != "" ? rule.filter.prefix : null
+      tags   = try(rule.filter.tags, null) != null ? { for tag in rule.filter.tags : tag.key => tag.value } : {}
+    }
+  } : {}
+
+  replication_rule_replica_kms_key_ids = local.replication_enabled ? {
+    for rule in var.replication_configuration.rules : rule.id => (
+      rule.destination.replica_kms_key_id != null ? rule.destination.replica_kms_key_id : try(rule.destination.encryption_configuration.replica_kms_key_id, null)
+    )
+  } : {}
+  replication_replica_kms_key_ids = distinct(compact(values(local.replication_rule_replica_kms_key_ids)))
+
+  # Rules replicating into the bucket itself. The name is compared rather than the ARN so the check runs at plan time
+  replication_self_destination_rules = local.replication_enabled ? [
+    for rule in var.replication_configuration.rules : rule.id
+    if try(regex("^arn:[^:]+:s3:::(.+)$", rule.destination.bucket)[0], null) == (var.bucket_name != null ? var.bucket_name : try(aws_s3_bucket.this[0].bucket, null))
+  ] : []
+
+  # SSE-KMS objects are only replicated when selected, so rules on a KMS source or with a replica key select them by default
+  replication_rules_without_replica_key = local.is_kms_encryption ? [
+    for id, key in local.replication_rule_replica_kms_key_ids : id if key == null
+  ] : []
+
+  # Notification helpers
+  notification_lambda_functions = try(var.notification_configuration.lambda_functions, null) != null ? var.notification_configuration.lambda_functions : []
+  notification_queues           = try(var.notification_configuration.queues, null) != null ? var.notification_configuration.queues : []
+  notification_topics           = try(var.notification_configuration.topics, null) != null ? var.notification_configuration.topics : []
+  notification_enabled          = var.create && (var.eventbridge_enabled || length(local.notification_lambda_functions) + length(local.notification_queues) + length(local.notification_topics) > 0)
+
+  # Access point helpers. Object Lambda access points without a declared supporting access point get one with defaults
+  object_lambda_supporting_access_points = {
+    for name, olap in var.object_lambda_access_points : name => olap.supporting_access_point != null ? olap.supporting_access_point : "${name}-ap"
+  }
+
+  access_points = merge(
+    {
+      for name in distinct(values(local.object_lambda_supporting_access_points)) : name => {
+        vpc_id                  = null
+        policy                  = null
+        block_public_acls       = true
+        block_public_policy     = true
+        ignore_public_acls      = true
+        restrict_public_buckets = true
+      }
+    },
+    var.access_points
+  )
+
+  # Destination bucket policy helpers. Deliveries are grouped by destination bucket because a bucket policy may only name its own bucket
+  destination_delivery_types = {
+    inventory = {
+      sid     = "AllowS3InventoryDelivery"
+      service = "s3.amazonaws.com"
+      conditions = {
+        "s3:x-amz-acl" = "bucket-owner-full-control"
+      }
+    }
+    analytics = {
+      sid     = "AllowS3AnalyticsExport"
+      service = "s3.amazonaws.com"
+      conditions = {
+        "s3:x-amz-acl" = "bucket-owner-full-control"
+      }
+    }
+    logging = {
+      sid        = "AllowS3ServerAccessLogs"
+      service    = "logging.s3.amazonaws.com"
+      conditions = {}
+    }
+  }
+
+  destination_deliveries = var.create ? concat(
+    [
+      for config in values(var.inventory_configurations) : {
+        type       = "inventory"
+        bucket_arn = config.destination_bucket_arn
+        resource   = "${config.destination_bucket_arn}/${config.destination_prefix != null ? config.destination_prefix : ""}*"
+      }
+    ],
+    [
+      for config in values(var.analytics_configurations) : {
+        type       = "analytics"
+        bucket_arn = config.export.destination_bucket_arn
+        resource   = "${config.export.destination_bucket_arn}/${config.export.prefix != null ? config.export.prefix : ""}*"
+      } if config.export != null
+    ],
+    var.logging != null ? [
+      {
+        type       = "logging"
+        bucket_arn = "arn:${data.aws_partition.current.partition}:s3:::${var.logging.target_bucket}"
+        resource   = "arn:${data.aws_partition.current.partition}:s3:::${var.logging.target_bucket}/${var.logging.target_prefix}*"
+      }
+    ] : []
+  ) : []
+
+  destination_bucket_policies = {
+    for bucket_arn in distinct([for delivery in local.destination_deliveries : delivery.bucket_arn]) : bucket_arn => jsonencode({
+      Version = "2012-10-17"
+      Statement = [
+        for type, settings in local.destination_delivery_types : {
+          Sid       = settings.sid
+          Effect    = "Allow"
+          Principal = { Service = settings.service }
+          Action    = "s3:PutObject"
+          Resource  = distinct([for delivery in local.destination_deliveries : delivery.resource if delivery.bucket_arn == bucket_arn && delivery.type == type])
+          Condition = {
+            ArnLike      = { "aws:SourceArn" = aws_s3_bucket.this[0].arn }
+            StringEquals = merge({ "aws:SourceAccount" = data.aws_caller_identity.current.account_id }, settings.conditions)
+          }
+        } if anytrue([for delivery in local.destination_deliveries : delivery.bucket_arn == bucket_arn && delivery.type == type])
+      ]
+    })
+  }
+
+  # Request alarm helpers. Each request metrics configuration gets one alarm per error class
+  request_alarms = var.create && var.create_request_alarms ? {
+    for pair in setproduct([for config in var.metrics_configurations : config.id], ["4xx", "5xx"]) : "${pair[0]}-${pair[1]}" => {
+      filter_id   = pair[0]
+      error_class = pair[1]
+    }
+  } : {}
+
+  # Replication latency is only published for rules with replication metrics, which Replication Time Control turns on by default
+  replication_latency_alarms = local.replication_enabled && var.create_replication_latency_alarm ? {
+    for rule in var.replication_configuration.rules : rule.id => {
+      destination_bucket = try(regex("^arn:[^:]+:s3:::(.+)$", rule.destination.bucket)[0], rule.destination.bucket)
+    }
+    if try(rule.destination.metrics.status, null) == "Enabled" || (rule.destination.metrics == null && try(rule.destination.replication_time.status, "Disabled") == "Enabled")
+  } : {}
 
   # Computed values for outputs
-  bucket_url = "https://${aws_s3_bucket.this.bucket}.s3.${data.aws_region.current.name}.amazonaws.com"
+  bucket_url     = var.create ? "https://${aws_s3_bucket.this[0].bucket}.s3.${data.aws_region.current.name}.amazonaws.com" : null
+  website_domain = try(aws_s3_bucket_website_configuration.this[0].website_domain, null)
+
+  # Disabled when versioning is not managed or the bucket is not created
+  versioning_status = try(aws_s3_bucket_versioning.this[0].versioning_configuration[0].status, "Disabled")
 } 
\ No newline at end of file
@@ -4,43 +4,83 @@
 # Data source for current region
 data "aws_region" "current" {}
 
+# Data source for the current account, used in destination bucket policy conditions
+data "aws_caller_identity" "current" {}
+
+# Data source for the current partition, used to build destination bucket ARNs
+data "aws_partition" "current" {}
+
 # S3 Bucket
 resource "aws_s3_bucket" "this" {
-  bucket = var.bucket_name
+  count = var.create ? 1 : 0
 
-  # Prevent accidental deletion
-  lifecycle {
-    prevent_destroy = var.prevent_destroy
-  }
+  bucket              = var.bucket_name
+  bucket_prefix       = var.bucket_prefix
+  force_destroy       = var.force_destroy
+  object_lock_enabled = local.object_lock_enabled
 
   tags = local.computed_tags
+
+  lifecycle {
+    precondition {
+      condition     = var.expected_region == null || var.expected_region == data.aws_region.current.name
+      error_message = "The provider region ${data.aws_region.current.name} does not match expected_region ${coalesce(var.expected_region, "none")}. Check the provider configuration before creating the bucket."
+    }
+
+    # S3 turns versioning on with object lock and refuses to suspend it afterwards
+    precondition {
+      condition     = !local.object_lock_enabled || var.versioning_status == "Enabled"
+      error_message = "Object lock requires versioning. Set versioning_status = \"Enabled\" or object_lock_enabled = false."
+    }
+  }
 }
 
 # S3 Bucket Versioning
 resource "aws_s3_bucket_versioning" "this" {
-  bucket = aws_s3_bucket.this.id
-  
+  count  = var.create && var.versioning_status != "Disabled" ? 1 : 0
+  bucket = aws_s3_bucket.this[0].id
+  mfa    = var.mfa
+
+  expected_bucket_owner = var.expected_bucket_owner
+
   versioning_configuration {
-    status = var.enable_versioning ? "Enabled" : "Disabled"
+    status     = var.versioning_status
+    mfa_delete = var.mfa_delete
   }
 }
 
 # S3 Bucket Server Side Encryption Configuration
 resource "aws_s3_bucket_server_side_encryption_configuration" "this" {
-  bucket = aws_s3_bucket.this.id
+  count  = var.create ? 1 : 0
+  bucket = aws_s3_bucket.this[0].id
+
+  expected_bucket_owner = var.expected_bucket_owner
 
   rule {
     apply_server_side_encryption_by_default {
-      sse_algorithm     = var.encryption_algorithm
-      kms_master_key_id = var.kms_key_id
+      sse_algorithm     = local.encryption.sse_algorithm
+      kms_master_key_id = local.is_kms_encryption ? local.kms_key_arn : null
+    }
+    bucket_key_enabled = local.is_kms_encryption ? local.encryption.bucket_key_enabled : null
+  }
+
+  lifecycle {
+    precondition {
+      condition     = contains(["AES256", "aws:kms"], local.encryption.sse_algorithm)
+      error_message = "Bucket default encryption supports only 'AES256' (SSE-S3) and 'aws:kms' (SSE-KMS). SSE-C is not available as a bucket default; send customer-provided keys with each request instead."
+    }
+
+    precondition {
+      condition     = !local.requires_kms_key
+      error_message = "A KMS key must be set in kms_key_arn, kms_key_alias or encryption.kms_master_key_id when SSE-KMS is used and create_kms_key is false. The key policy must also allow the principals writing to the bucket to use the key."
     }
-    bucket_key_enabled = var.bucket_key_enabled
   }
 }
 
 # S3 Bucket Public Access Block
 resource "aws_s3_bucket_public_access_block" "this" {
-  bucket = aws_s3_bucket.this.id
+  count  = var.create && var.manage_public_access_block ? 1 : 0
+  bucket = aws_s3_bucket.this[0].id
 
   block_public_acls       = var.block_public_acls
   block_public_policy     = var.block_public_policy
@@ -50,19 +90,69 @@ resource "aws_s3_bucket_public_access_block" "this" {
 
 # S3 Bucket Ownership Controls
 resource "aws_s3_bucket_ownership_controls" "this" {
-  bucket = aws_s3_bucket.this.id
+  count  = var.create ? 1 : 0
+  bucket = aws_s3_bucket.this[0].id
 
   rule {
     object_ownership = var.object_ownership
   }
 }
 
+# Canonical user ID of the bucket owner, which explicit grants must name
+data "aws_canonical_user_id" "current" {
+  count = var.create && length(var.grants) > 0 ? 1 : 0
+}
+
 # S3 Bucket ACL
 resource "aws_s3_bucket_acl" "this" {
-  count  = var.acl != null ? 1 : 0
-  bucket = aws_s3_bucket.this.id
+  count  = var.create && (var.acl != null || length(var.grants) > 0) ? 1 : 0
+  bucket = aws_s3_bucket.this[0].id
   acl    = var.acl
 
+  expected_bucket_owner = var.expected_bucket_owner
+
+  # An access control policy replaces the whole ACL, so the owner's FULL_CONTROL grant is always included
+  dynamic "access_control_policy" {
+    for_each = length(var.grants) > 0 ? [data.aws_canonical_user_id.current[0].id] : []
+    content {
+      grant {
+        grantee {
+          type = "CanonicalUser"
+          id   = access_control_policy.value
+        }
+        permission = "FULL_CONTROL"
+      }
+
+      dynamic "grant" {
+        for_each = var.grants
+        content {
+          grantee {
+            type = grant.value.grantee_type
+            id   = grant.value.grantee_id
+            uri  = grant.value.uri
+          }
+          permission = grant.value.permission
+        }
+      }
+
+      owner {
+        id = access_control_policy.value
+      }
+    }
+  }
+
+  lifecycle {
+    precondition {
+      condition     = var.object_ownership != "BucketOwnerEnforced"
+      error_message = "acl and grants require object_ownership to be BucketOwnerPreferred or ObjectWriter. ACLs are disabled when it is BucketOwnerEnforced."
+    }
+
+    precondition {
+      condition     = !local.public_acl_requested || !var.manage_public_access_block || (!var.block_public_acls && !var.ignore_public_acls)
+      error_message = "A public acl (${coalesce(var.acl, "grants")}) requires block_public_acls and ignore_public_acls to be false. Otherwise S3 rejects or ignores the grant."
+    }
+  }
+
   depends_on = [
     aws_s3_bucket_public_access_block.this,
     aws_s3_bucket_ownership_controls.this
@@ -71,27 +161,44 @@ resource "aws_s3_bucket_acl" "this" {
 
 # S3 Bucket Lifecycle Configuration
 resource "aws_s3_bucket_lifecycle_configuration" "this" {
-  count  = length(var.lifecycle_rules) > 0 ? 1 : 0
-  bucket = aws_s3_bucket.this.id
+  count  = var.create && length(local.lifecycle_rules) > 0 ? 1 : 0
+  bucket = aws_s3_bucket.this[0].id
+
+  expected_bucket_owner = var.expected_bucket_owner
+
+  transition_default_minimum_object_size = var.transition_default_minimum_object_size
 
   dynamic "rule" {
-    for_each = var.lifecycle_rules
+    for_each = local.lifecycle_rules
     content {
       id     = rule.value.id
       status = rule.value.status
 
+      # Rules without a filter get an empty one so they apply to the whole bucket
       dynamic "filter" {
-        for_each = rule.value.filter != null ? [rule.value.filter] : []
+        for_each = [rule.value.filter]
         content {
-          prefix = filter.value.prefix
+          prefix                   = local.lifecycle_and_filters[rule.value.id] ? null : try(filter.value.prefix, null)
+          object_size_greater_than = local.lifecycle_and_filters[rule.value.id] ? null : try(filter.value.object_size_greater_than, null)
+          object_size_less_than    = local.lifecycle_and_filters[rule.value.id] ? null : try(filter.value.object_size_less_than, null)
 
           dynamic "tag" {
-            for_each = filter.value.tags != null ? filter.value.tags : []
+            for_each = !local.lifecycle_and_filters[rule.value.id] && try(filter.value.tags, null) != null ? filter.value.tags : []
             content {
               key   = tag.value.key
               value = tag.value.value
             }
           }
+
+          dynamic "and" {
+            for_each = local.lifecycle_and_filters[rule.value.id] ? [filter.value] : []
+            content {
+              prefix                   = and.value.prefix
+              tags                     = and.value.tags != null ? { for tag in and.value.tags : tag.key => tag.value } : null
+              object_size_greater_than = and.value.object_size_greater_than
+              object_size_less_than    = and.value.object_size_less_than
+            }
+          }
         }
       }
 
@@ -106,7 +213,9 @@ resource "aws_s3_bucket_lifecycle_configuration" "this" {
       dynamic "expiration" {
         for_each = rule.value.expiration != null ? [rule.value.expiration] : []
         content {
-          days = expiration.value.days
+          days                         = expiration.value.days
+          date                         = expiration.value.date
+          expired_object_delete_marker = expiration.value.expired_object_delete_marker
         }
       }
 
@@ -121,7 +230,8 @@ resource "aws_s3_bucket_lifecycle_configuration" "this" {
       dynamic "noncurrent_version_expiration" {
         for_each = rule.value.noncurrent_version_expiration != null ? [rule.value.noncurrent_version_expiration] : []
         content {
-          noncurrent_days = noncurrent_version_expiration.value.noncurrent_days
+          noncurrent_days           = noncurrent_version_expiration.value.noncurrent_days
+          newer_noncurrent_versions = noncurrent_version_expiration.value.newer_noncurrent_versions
         }
       }
 
@@ -133,16 +243,26 @@ resource "aws_s3_bucket_lifecycle_configuration" "this" {
       }
     }
   }
+
+  lifecycle {
+    precondition {
+      condition     = length(local.lifecycle_rules_overlapping_abort) == 0
+      error_message = "abort_incomplete_multipart_upload_days overlaps lifecycle rules that already abort multipart uploads for the whole bucket: ${join(", ", local.lifecycle_rules_overlapping_abort)}."
+    }
+  }
 }
 
 # S3 Bucket CORS Configuration
 resource "aws_s3_bucket_cors_configuration" "this" {
-  count  = length(var.cors_rules) > 0 ? 1 : 0
-  bucket = aws_s3_bucket.this.id
+  count  = var.create && length(var.cors_rules) > 0 ? 1 : 0
+  bucket = aws_s3_bucket.this[0].id
+
+  expected_bucket_owner = var.expected_bucket_owner
 
   dynamic "cors_rule" {
     for_each = var.cors_rules
     content {
+      id              = cors_rule.value.id
       allowed_headers = cors_rule.value.allowed_headers
       allowed_methods = cors_rule.value.allowed_methods
       allowed_origins = cors_rule.value.allowed_origins
@@ -154,18 +274,20 @@ resource "aws_s3_bucket_cors_configuration" "this" {
 
 # S3 Bucket Website Configuration
 resource "aws_s3_bucket_website_configuration" "this" {
-  count  = var.website_configuration != null ? 1 : 0
-  bucket = aws_s3_bucket.this.id
+  count  = var.create && var.website_configuration != null ? 1 : 0
+  bucket = aws_s3_bucket.this[0].id
+
+  expected_bucket_owner = var.expected_bucket_owner
 
   dynamic "index_document" {
-    for_each = var.website_configuration.index_document != null ? [var.website_configuration.index_document] : []
+    for_each = local.website_index_document != null ? [local.website_index_document] : []
     content {
       suffix = index_document.value
     }
   }
 
   dynamic "error_document" {
-    for_each = var.website_configuration.error_document != null ? [var.website_configuration.error_document] : []
+    for_each = local.website_error_document != null ? [local.website_error_document] : []
     content {
       key = error_document.value
     }
@@ -178,15 +300,49 @@ resource "aws_s3_bucket_website_configuration" "this" {
       protocol  = redirect_all_requests_to.value.protocol
     }
   }
+
+  dynamic "routing_rule" {
+    for_each = var.website_configuration.routing_rules
+    content {
+      dynamic "condition" {
+        for_each = routing_rule.value.condition != null ? [routing_rule.value.condition] : []
+        content {
+          key_prefix_equals               = condition.value.key_prefix_equals
+          http_error_code_returned_equals = condition.value.http_error_code_returned_equals
+        }
+      }
+
+      redirect {
+        host_name               = routing_rule.value.redirect.host_name
+        http_redirect_code      = routing_rule.value.redirect.http_redirect_code
+        protocol                = routing_rule.value.redirect.protocol
+        replace_key_prefix_with = routing_rule.value.redirect.replace_key_prefix_with
+        replace_key_with        = routing_rule.value.redirect.replace_key_with
+      }
+    }
+  }
+}
+
+# Lambda Permissions for S3 Bucket Notifications
+resource "aws_lambda_permission" "notification" {
+  for_each = local.notification_enabled ? toset([for function in local.notification_lambda_functions : function.lambda_function_arn]) : toset([])
+
+  statement_id_prefix = "AllowExecutionFromS3Bucket"
+  action              = "lambda:InvokeFunction"
+  function_name       = each.value
+  principal           = "s3.amazonaws.com"
+  source_arn          = aws_s3_bucket.this[0].arn
 }
 
 # S3 Bucket Notification Configuration
 resource "aws_s3_bucket_notification" "this" {
-  count  = var.notification_configuration != null ? 1 : 0
-  bucket = aws_s3_bucket.this.id
+  count  = local.notification_enabled ? 1 : 0
+  bucket = aws_s3_bucket.this[0].id
+
+  eventbridge = var.eventbridge_enabled
 
   dynamic "lambda_function" {
-    for_each = var.notification_configuration.lambda_functions != null ? var.notification_configuration.lambda_functions : []
+    for_each = local.notification_lambda_functions
     content {
       lambda_function_arn = lambda_function.value.lambda_function_arn
       events              = lambda_function.value.events
@@ -196,7 +352,7 @@ resource "aws_s3_bucket_notification" "this" {
   }
 
   dynamic "queue" {
-    for_each = var.notification_configuration.queues != null ? var.notification_configuration.queues : []
+    for_each = local.notification_queues
     content {
       queue_arn     = queue.value.queue_arn
       events        = queue.value.events
@@ -206,7 +362,7 @@ resource "aws_s3_bucket_notification" "this" {
   }
 
   dynamic "topic" {
-    for_each = var.notification_configuration.topics != null ? var.notification_configuration.topics : []
+    for_each = local.notification_topics
     content {
       topic_arn     = topic.value.topic_arn
       events        = topic.value.events
@@ -214,22 +370,350 @@ resource "aws_s3_bucket_notification" "this" {
       filter_suffix = topic.value.filter_suffix
     }
   }
+
+  depends_on = [aws_lambda_permission.notification]
+}
+
+# S3 Bucket Policy Document
+data "aws_iam_policy_document" "bucket_policy" {
+  count = local.attach_policy ? 1 : 0
+
+  source_policy_documents = var.bucket_policy != null ? [var.bucket_policy] : []
+
+  dynamic "statement" {
+    for_each = local.enforce_ssl ? [1] : []
+    content {
+      sid     = "DenyInsecureTransport"
+      effect  = "Deny"
+      actions = ["s3:*"]
+      resources = [
+        aws_s3_bucket.this[0].arn,
+        "${aws_s3_bucket.this[0].arn}/*"
+      ]
+
+      principals {
+        type        = "*"
+        identifiers = ["*"]
+      }
+
+      condition {
+        test     = "Bool"
+        variable = "aws:SecureTransport"
+        values   = ["false"]
+      }
+    }
+  }
+
+  dynamic "statement" {
+    for_each = local.enforce_min_tls_version != null ? [local.enforce_min_tls_version] : []
+    content {
+      sid     = "DenyOutdatedTLS"
+      effect  = "Deny"
+      actions = ["s3:*"]
+      resources = [
+        aws_s3_bucket.this[0].arn,
+        "${aws_s3_bucket.this[0].arn}/*"
+      ]
+
+      principals {
+        type        = "*"
+        identifiers = ["*"]
+      }
+
+      condition {
+        test     = "NumericLessThan"
+        variable = "s3:TlsVersion"
+        values   = [statement.value]
+      }
+    }
+  }
+
+  dynamic "statement" {
+    for_each = var.deny_unencrypted_uploads ? [1] : []
+    content {
+      sid       = "DenyUnencryptedUploads"
+      effect    = "Deny"
+      actions   = ["s3:PutObject"]
+      resources = ["${aws_s3_bucket.this[0].arn}/*"]
+
+      principals {
+        type        = "*"
+        identifiers = ["*"]
+      }
+
+      condition {
+        test     = "Null"
+        variable = "s3:x-amz-server-side-encryption"
+        values   = ["true"]
+      }
+    }
+  }
+
+  dynamic "statement" {
+    for_each = var.required_upload_sse_algorithm != null ? [var.required_upload_sse_algorithm] : []
+    content {
+      sid       = "DenyIncorrectEncryptionHeader"
+      effect    = "Deny"
+      actions   = ["s3:PutObject"]
+      resources = ["${aws_s3_bucket.this[0].arn}/*"]
+
+      principals {
+        type        = "*"
+        identifiers = ["*"]
+      }
+
+      condition {
+        test     = "StringNotEquals"
+        variable = "s3:x-amz-server-side-encryption"
+        values   = [statement.value]
+      }
+    }
+  }
+
+  dynamic "statement" {
+    for_each = var.required_upload_kms_key_arn != null ? [var.required_upload_kms_key_arn] : []
+    content {
+      sid       = "DenyIncorrectKMSKey"
+      effect    = "Deny"
+      actions   = ["s3:PutObject"]
+      resources = ["${aws_s3_bucket.this[0].arn}/*"]
+
+      principals {
+        type        = "*"
+        identifiers = ["*"]
+      }
+
+      condition {
+        test     = "StringNotEquals"
+        variable = "s3:x-amz-server-side-encryption-aws-kms-key-id"
+        values   = [statement.value]
+      }
+    }
+  }
+
+  dynamic "statement" {
+    for_each = length(var.cloudfront_oai_iam_arns) > 0 ? [var.cloudfront_oai_iam_arns] : []
+    content {
+      sid       = "AllowCloudFrontOAIRead"
+      effect    = "Allow"
+      actions   = ["s3:GetObject"]
+      resources = ["${aws_s3_bucket.this[0].arn}/*"]
+
+      principals {
+        type        = "AWS"
+        identifiers = statement.value
+      }
+    }
+  }
+
+  dynamic "statement" {
+    for_each = length(var.cloudfront_distribution_arns) > 0 ? [var.cloudfront_distribution_arns] : []
+    content {
+      sid       = "AllowCloudFrontOACRead"
+      effect    = "Allow"
+      actions   = ["s3:GetObject"]
+      resources = ["${aws_s3_bucket.this[0].arn}/*"]
+
+      principals {
+        type        = "Service"
+        identifiers = ["cloudfront.amazonaws.com"]
+      }
+
+      condition {
+        test     = "StringEquals"
+        variable = "AWS:SourceArn"
+        values   = statement.value
+      }
+    }
+  }
+
+  dynamic "statement" {
+    for_each = length(var.cross_account_read_principals) > 0 ? [var.cross_account_read_principals] : []
+    content {
+      sid     = "AllowCrossAccountRead"
+      effect  = "Allow"
+      actions = ["s3:GetObject", "s3:ListBucket"]
+      resources = [
+        aws_s3_bucket.this[0].arn,
+        "${aws_s3_bucket.this[0].arn}/*"
+      ]
+
+      principals {
+        type        = "AWS"
+        identifiers = statement.value
+      }
+    }
+  }
+
+  dynamic "statement" {
+    for_each = length(var.object_lock_governance_bypass_principals) > 0 ? [var.object_lock_governance_bypass_principals] : []
+    content {
+      sid       = "AllowGovernanceRetentionBypass"
+      effect    = "Allow"
+      actions   = ["s3:BypassGovernanceRetention"]
+      resources = ["${aws_s3_bucket.this[0].arn}/*"]
+
+      principals {
+        type        = "AWS"
+        identifiers = statement.value
+      }
+    }
+  }
+
+  # Object access is denied outside the endpoints; bucket management stays
+  # reachable so Terraform can still read and update the bucket
+  dynamic "statement" {
+    for_each = length(var.restrict_to_vpc_endpoints) > 0 ? [var.restrict_to_vpc_endpoints] : []
+    content {
+      sid    = "DenyAccessOutsideVPCEndpoints"
+      effect = "Deny"
+      actions = [
+        "s3:GetObject*",
+        "s3:PutObject*",
+        "s3:DeleteObject*",
+        "s3:ListBucket"
+      ]
+      resources = [
+        aws_s3_bucket.this[0].arn,
+        "${aws_s3_bucket.this[0].arn}/*"
+      ]
+
+      principals {
+        type        = "*"
+        identifiers = ["*"]
+      }
+
+      condition {
+        test     = "StringNotEquals"
+        variable = "aws:SourceVpce"
+        values   = statement.value
+      }
+    }
+  }
 }
 
 # S3 Bucket Policy
 resource "aws_s3_bucket_policy" "this" {
-  count  = var.bucket_policy != null ? 1 : 0
-  bucket = aws_s3_bucket.this.id
-  policy = var.bucket_policy
+  count  = local.attach_policy ? 1 : 0
+  bucket = aws_s3_bucket.this[0].id
+  policy = data.aws_iam_policy_document.bucket_policy[0].json
+
+  lifecycle {
+    precondition {
+      condition     = length(setintersection(local.generated_policy_sids, local.custom_policy_sids)) == 0
+      error_message = "bucket_policy statements reuse Sids of generated statements: ${join(", ", setintersection(local.generated_policy_sids, local.custom_policy_sids))}. Rename them so both statements are kept."
+    }
+
+    precondition {
+      condition     = !local.public_policy_requested || !var.manage_public_access_block || (!var.block_public_policy && !var.restrict_public_buckets)
+      error_message = "bucket_policy grants public access, which requires block_public_policy and restrict_public_buckets to be false. Otherwise S3 rejects the policy or blocks anonymous requests."
+    }
+
+    precondition {
+      condition     = length(var.object_lock_governance_bypass_principals) == 0 || local.object_lock_enabled
+      error_message = "object_lock_governance_bypass_principals requires object lock. Set object_lock_enabled or object_lock_configuration."
+    }
+  }
 
   depends_on = [aws_s3_bucket_public_access_block.this]
 }
 
+# IAM Role for S3 Replication
+data "aws_iam_policy_document" "replication_assume_role" {
+  count = local.create_replication_role ? 1 : 0
+
+  statement {
+    effect  = "Allow"
+    actions = ["sts:AssumeRole"]
+
+    principals {
+      type        = "Service"
+      identifiers = ["s3.amazonaws.com"]
+    }
+  }
+}
+
+resource "aws_iam_role" "replication" {
+  count              = local.create_replication_role ? 1 : 0
+  name_prefix        = "s3-replication-"
+  assume_role_policy = data.aws_iam_policy_document.replication_assume_role[0].json
+
+  tags = local.computed_tags
+}
+
+data "aws_iam_policy_document" "replication" {
+  count = local.create_replication_role ? 1 : 0
+
+  statement {
+    effect = "Allow"
+    actions = [
+      "s3:GetReplicationConfiguration",
+      "s3:ListBucket"
+    ]
+    resources = [aws_s3_bucket.this[0].arn]
+  }
+
+  statement {
+    effect = "Allow"
+    actions = [
+      "s3:GetObjectVersionForReplication",
+      "s3:GetObjectVersionAcl",
+      "s3:GetObjectVersionTagging"
+    ]
+    resources = ["${aws_s3_bucket.this[0].arn}/*"]
+  }
+
+  statement {
+    effect = "Allow"
+    actions = [
+      "s3:ReplicateObject",
+      "s3:ReplicateDelete",
+      "s3:ReplicateTags"
+    ]
+    resources = local.replication_destination_object_arns
+  }
+
+  # Changing replica ownership to the destination account needs its own permission
+  dynamic "statement" {
+    for_each = length(local.replication_owner_override_object_arns) > 0 ? [local.replication_owner_override_object_arns] : []
+    content {
+      effect    = "Allow"
+      actions   = ["s3:ObjectOwnerOverrideToBucketOwner"]
+      resources = statement.value
+    }
+  }
+
+  dynamic "statement" {
+    for_each = local.kms_key_arn != null ? [local.kms_key_arn] : []
+    content {
+      effect    = "Allow"
+      actions   = ["kms:Decrypt"]
+      resources = [statement.value]
+    }
+  }
+
+  dynamic "statement" {
+    for_each = length(local.replication_replica_kms_key_ids) > 0 ? [local.replication_replica_kms_key_ids] : []
+    content {
+      effect    = "Allow"
+      actions   = ["kms:Encrypt"]
+      resources = statement.value
+    }
+  }
+}
+
+resource "aws_iam_role_policy" "replication" {
+  count  = local.create_replication_role ? 1 : 0
+  name   = "s3-replication"
+  role   = aws_iam_role.replication[0].id
+  policy = data.aws_iam_policy_document.replication[0].json
+}
+
 # S3 Bucket Replication Configuration
 resource "aws_s3_bucket_replication_configuration" "this" {
-  count  = var.replication_configuration != null ? 1 : 0
-  bucket = aws_s3_bucket.this.id
-  role   = var.replication_configuration.role
+  count  = local.replication_enabled ? 1 : 0
+  bucket = aws_s3_bucket.this[0].id
+  role   = local.replication_role_arn
 
   dynamic "rule" {
     for_each = var.replication_configuration.rules
@@ -238,17 +722,24 @@ resource "aws_s3_bucket_replication_configuration" "this" {
       status   = rule.value.status
       priority = rule.value.priority
 
-      dynamic "filter" {
-        for_each = rule.value.filter != null ? [rule.value.filter] : []
-        content {
-          prefix = filter.value.prefix
+      # Every rule uses the V2 schema filter: a prefix, a single tag, or an
+      # and block when a prefix and tags or several tags are combined
+      filter {
+        prefix = length(local.replication_filters[rule.value.id].tags) == 0 ? local.replication_filters[rule.value.id].prefix : null
 
-          dynamic "tag" {
-            for_each = filter.value.tags != null ? filter.value.tags : []
-            content {
-              key   = tag.value.key
-              value = tag.value.value
-            }
+        dynamic "tag" {
+          for_each = length(local.replication_filters[rule.value.id].tags) == 1 && local.replication_filters[rule.value.id].prefix == null ? local.replication_filters[rule.value.id].tags : {}
+          content {
+            key   = tag.key
+            value = tag.value
+          }
+        }
+
+        dynamic "and" {
+          for_each = length(local.replication_filters[rule.value.id].tags) > 1 || (length(local.replication_filters[rule.value.id].tags) == 1 && local.replication_filters[rule.value.id].prefix != null) ? [local.replication_filters[rule.value.id]] : []
+          content {
+            prefix = and.value.prefix
+            tags   = and.value.tags
           }
         }
       }
@@ -256,9 +747,9 @@ resource "aws_s3_bucket_replication_configuration" "this" {
       dynamic "destination" {
         for_each = rule.value.destination != null ? [rule.value.destination] : []
         content {
-          bucket             = destination.value.bucket
-          storage_class      = destination.value.storage_class
-          replica_kms_key_id = destination.value.replica_kms_key_id
+          bucket        = destination.value.bucket
+          storage_class = destination.value.storage_class
+          account       = destination.value.account
 
           dynamic "access_control_translation" {
             for_each = destination.value.access_control_translation != null ? [destination.value.access_control_translation] : []
@@ -268,14 +759,29 @@ resource "aws_s3_bucket_replication_configuration" "this" {
           }
 
           dynamic "encryption_configuration" {
-            for_each = destination.value.encryption_configuration != null ? [destination.value.encryption_configuration] : []
+            for_each = local.replication_rule_replica_kms_key_ids[rule.value.id] != null ? [local.replication_rule_replica_kms_key_ids[rule.value.id]] : []
             content {
-              replica_kms_key_id = encryption_configuration.value.replica_kms_key_id
+              replica_kms_key_id = encryption_configuration.value
             }
           }
 
+          dynamic "replication_time" {
+            for_each = destination.value.replication_time != null ? [destination.value.replication_time] : []
+            content {
+              status = replication_time.value.status
+
+              time {
+                minutes = replication_time.value.minutes
+              }
+            }
+          }
+
+          # Replication Time Control requires metrics, so they are enabled with it by default
           dynamic "metrics" {
-            for_each = destination.value.metrics != null ? [destination.value.metrics] : []
+            for_each = destination.value.metrics != null ? [destination.value.metrics] : try(destination.value.replication_time.status, "Disabled") == "Enabled" ? [{
+              status          = "Enabled"
+              event_threshold = { minutes = destination.value.replication_time.minutes }
+            }] : []
             content {
               status = metrics.value.status
 
@@ -291,7 +797,9 @@ resource "aws_s3_bucket_replication_configuration" "this" {
       }
 
       dynamic "source_selection_criteria" {
-        for_each = rule.value.source_selection_criteria != null ? [rule.value.source_selection_criteria] : []
+        for_each = rule.value.source_selection_criteria != null ? [rule.value.source_selection_criteria] : local.is_kms_encryption || local.replication_rule_replica_kms_key_ids[rule.value.id] != null ? [{
+          sse_kms_encrypted_objects = { status = "Enabled" }
+        }] : []
         content {
           dynamic "sse_kms_encrypted_objects" {
             for_each = source_selection_criteria.value.sse_kms_encrypted_objects != null ? [source_selection_criteria.value.sse_kms_encrypted_objects] : []
@@ -302,37 +810,44 @@ resource "aws_s3_bucket_replication_configuration" "this" {
         }
       }
 
-      dynamic "delete_marker_replication" {
-        for_each = rule.value.delete_marker_replication != null ? [rule.value.delete_marker_replication] : []
-        content {
-          status = delete_marker_replication.value.status
-        }
+      # Required by the V2 schema, so it defaults to Disabled
+      delete_marker_replication {
+        status = try(rule.value.delete_marker_replication.status, "Disabled")
       }
     }
   }
 
-  depends_on = [aws_s3_bucket_versioning.this]
+  lifecycle {
+    precondition {
+      condition     = length(local.replication_rules_without_replica_key) == 0
+      error_message = "The source bucket uses SSE-KMS, so replication rules need a destination replica_kms_key_id to encrypt replicas. Missing for: ${join(", ", local.replication_rules_without_replica_key)}."
+    }
+
+    precondition {
+      condition     = length(local.replication_self_destination_rules) == 0
+      error_message = "A bucket cannot replicate to itself. Replication rules with the source bucket as destination: ${join(", ", local.replication_self_destination_rules)}."
+    }
+  }
+
+  depends_on = [
+    aws_s3_bucket_versioning.this,
+    aws_iam_role_policy.replication
+  ]
 }
 
 # S3 Bucket Intelligent Tiering Configuration
 resource "aws_s3_bucket_intelligent_tiering_configuration" "this" {
-  for_each = { for idx, config in var.intelligent_tiering_configurations : config.id => config }
+  for_each = var.create ? { for idx, config in var.intelligent_tiering_configurations : config.id => config } : {}
   
-  bucket = aws_s3_bucket.this.id
+  bucket = aws_s3_bucket.this[0].id
   name   = each.value.name
+  status = each.value.status
 
   dynamic "filter" {
     for_each = each.value.filter != null ? [each.value.filter] : []
     content {
       prefix = filter.value.prefix
-
-      dynamic "tag" {
-        for_each = filter.value.tags != null ? filter.value.tags : []
-        content {
-          key   = tag.value.key
-          value = tag.value.value
-        }
-      }
+      tags   = filter.value.tags != null ? { for tag in filter.value.tags : tag.key => tag.value } : null
     }
   }
 
@@ -347,8 +862,10 @@ resource "aws_s3_bucket_intelligent_tiering_configuration" "this" {
 
 # S3 Bucket Object Lock Configuration
 resource "aws_s3_bucket_object_lock_configuration" "this" {
-  count  = var.object_lock_configuration != null ? 1 : 0
-  bucket = aws_s3_bucket.this.id
+  count  = var.create && var.object_lock_configuration != null ? 1 : 0
+  bucket = aws_s3_bucket.this[0].id
+
+  expected_bucket_owner = var.expected_bucket_owner
 
   dynamic "rule" {
     for_each = var.object_lock_configuration.rules != null ? var.object_lock_configuration.rules : []
@@ -362,4 +879,322 @@ resource "aws_s3_bucket_object_lock_configuration" "this" {
   }
 
   depends_on = [aws_s3_bucket_versioning.this]
-} 
\ No newline at end of file
+}
+
+# S3 Bucket Logging
+resource "aws_s3_bucket_logging" "this" {
+  count  = var.create && var.logging != null ? 1 : 0
+  bucket = aws_s3_bucket.this[0].id
+
+  expected_bucket_owner = var.expected_bucket_owner
+
+  target_bucket = var.logging.target_bucket
+  target_prefix = var.logging.target_prefix
+
+  # Simple keys are the S3 default, so the block is only rendered for partitioned keys
+  dynamic "target_object_key_format" {
+    for_each = var.logging.target_object_key_format == "partitioned" ? [var.logging.partition_date_source] : []
+    content {
+      partitioned_prefix {
+        partition_date_source = target_object_key_format.value
+      }
+    }
+  }
+}
+
+# S3 Bucket Inventory
+resource "aws_s3_bucket_inventory" "this" {
+  for_each = var.create ? var.inventory_configurations : {}
+
+  bucket                   = aws_s3_bucket.this[0].id
+  name                     = each.key
+  enabled                  = each.value.enabled
+  included_object_versions = each.value.included_object_versions
+  optional_fields          = each.value.optional_fields
+
+  schedule {
+    frequency = each.value.schedule_frequency
+  }
+
+  destination {
+    bucket {
+      bucket_arn = each.value.destination_bucket_arn
+      prefix     = each.value.destination_prefix
+      format     = each.value.destination_format
+
+      dynamic "encryption" {
+        for_each = each.value.destination_encryption != null ? [each.value.destination_encryption] : []
+        content {
+          dynamic "sse_s3" {
+            for_each = encryption.value.sse_s3 != null ? [encryption.value.sse_s3] : []
+            content {}
+          }
+
+          dynamic "sse_kms" {
+            for_each = encryption.value.sse_kms != null ? [encryption.value.sse_kms] : []
+            content {
+              key_id = sse_kms.value.key_id
+            }
+          }
+        }
+      }
+    }
+  }
+
+  lifecycle {
+    precondition {
+      condition     = each.value.destination_bucket_arn != aws_s3_bucket.this[0].arn
+      error_message = "Inventory configuration ${each.key} delivers reports to the bucket it inventories. Use a separate destination bucket."
+    }
+  }
+}
+
+# S3 Bucket Analytics Configuration
+resource "aws_s3_bucket_analytics_configuration" "this" {
+  for_each = var.create ? var.analytics_configurations : {}
+
+  bucket = aws_s3_bucket.this[0].id
+  name   = each.key
+
+  dynamic "filter" {
+    for_each = each.value.filter_prefix != null || each.value.filter_tags != null ? [each.value] : []
+    content {
+      prefix = filter.value.filter_prefix
+      tags   = filter.value.filter_tags
+    }
+  }
+
+  dynamic "storage_class_analysis" {
+    for_each = each.value.export != null ? [each.value.export] : []
+    content {
+      data_export {
+        destination {
+          s3_bucket_destination {
+            bucket_arn = storage_class_analysis.value.destination_bucket_arn
+            prefix     = storage_class_analysis.value.prefix
+            format     = storage_class_analysis.value.format
+          }
+        }
+      }
+    }
+  }
+}
+
+# S3 Bucket Request Metrics
+resource "aws_s3_bucket_metric" "this" {
+  for_each = var.create ? { for config in var.metrics_configurations : config.id => config } : {}
+
+  bucket = aws_s3_bucket.this[0].id
+  name   = each.key
+
+  dynamic "filter" {
+    for_each = each.value.filter_prefix != null || each.value.filter_tags != null ? [each.value] : []
+    content {
+      prefix = filter.value.filter_prefix
+      tags   = filter.value.filter_tags
+    }
+  }
+}
+
+# CloudWatch Alarms on Request Metrics
+resource "aws_cloudwatch_metric_alarm" "request_errors" {
+  for_each = local.request_alarms
+
+  alarm_name        = "${aws_s3_bucket.this[0].id}-${each.value.filter_id}-${each.value.error_class}-errors"
+  alarm_description = "${each.value.error_class} errors on ${aws_s3_bucket.this[0].id} for request metrics ${each.value.filter_id}"
+  namespace         = "AWS/S3"
+  metric_name       = "${each.value.error_class}Errors"
+  statistic         = "Sum"
+  period            = 300
+
+  evaluation_periods  = 1
+  comparison_operator = "GreaterThanThreshold"
+  threshold           = each.value.error_class == "4xx" ? var.alarm_4xx_threshold : var.alarm_5xx_threshold
+  treat_missing_data  = "notBreaching"
+
+  dimensions = {
+    BucketName = aws_s3_bucket.this[0].id
+    FilterId   = aws_s3_bucket_metric.this[each.value.filter_id].name
+  }
+
+  tags = local.computed_tags
+}
+
+# Replication latency alarms, one per replication rule with metrics
+resource "aws_cloudwatch_metric_alarm" "replication_latency" {
+  for_each = local.replication_latency_alarms
+
+  alarm_name        = "${aws_s3_bucket.this[0].id}-${each.key}-replication-latency"
+  alarm_description = "Replication latency on ${aws_s3_bucket.this[0].id} to ${each.value.destination_bucket} for rule ${each.key}"
+  namespace         = "AWS/S3"
+  metric_name       = "ReplicationLatency"
+  statistic         = "Maximum"
+  period            = 300
+
+  evaluation_periods  = 1
+  comparison_operator = "GreaterThanThreshold"
+  threshold           = var.replication_latency_threshold_seconds
+  treat_missing_data  = "notBreaching"
+
+  dimensions = {
+    SourceBucket      = aws_s3_bucket.this[0].id
+    DestinationBucket = each.value.destination_bucket
+    RuleId            = each.key
+  }
+
+  tags = local.computed_tags
+
+  depends_on = [aws_s3_bucket_replication_configuration.this]
+}
+
+# S3 Bucket Transfer Acceleration
+resource "aws_s3_bucket_accelerate_configuration" "this" {
+  count  = var.create && var.acceleration_status != null ? 1 : 0
+  bucket = aws_s3_bucket.this[0].id
+  status = var.acceleration_status
+
+  expected_bucket_owner = var.expected_bucket_owner
+}
+
+# S3 Bucket Request Payment Configuration
+resource "aws_s3_bucket_request_payment_configuration" "this" {
+  count  = var.create && var.request_payer == "Requester" ? 1 : 0
+  bucket = aws_s3_bucket.this[0].id
+  payer  = var.request_payer
+
+  expected_bucket_owner = var.expected_bucket_owner
+}
+
+# S3 Access Points
+resource "aws_s3_access_point" "this" {
+  for_each = var.create ? local.access_points : {}
+
+  bucket = aws_s3_bucket.this[0].id
+  name   = each.key
+  policy = each.value.policy
+
+  public_access_block_configuration {
+    block_public_acls       = each.value.block_public_acls
+    block_public_policy     = each.value.block_public_policy
+    ignore_public_acls      = each.value.ignore_public_acls
+    restrict_public_buckets = each.value.restrict_public_buckets
+  }
+
+  dynamic "vpc_configuration" {
+    for_each = each.value.vpc_id != null ? [each.value.vpc_id] : []
+    content {
+      vpc_id = vpc_configuration.value
+    }
+  }
+}
+
+# S3 Object Lambda Access Points
+resource "aws_s3control_object_lambda_access_point" "this" {
+  for_each = var.create ? var.object_lambda_access_points : {}
+
+  name = each.key
+
+  configuration {
+    supporting_access_point     = aws_s3_access_point.this[local.object_lambda_supporting_access_points[each.key]].arn
+    allowed_features            = each.value.allowed_features
+    cloud_watch_metrics_enabled = each.value.cloud_watch_metrics_enabled
+
+    transformation_configuration {
+      actions = each.value.actions
+
+      content_transformation {
+        aws_lambda {
+          function_arn     = each.value.lambda_function_arn
+          function_payload = each.value.function_payload
+        }
+      }
+    }
+  }
+}
+
+# S3 Storage Lens Configuration
+resource "aws_s3control_storage_lens_configuration" "this" {
+  count     = var.create && var.create_storage_lens_configuration ? 1 : 0
+  config_id = var.storage_lens.id
+
+  storage_lens_configuration {
+    enabled = var.storage_lens.enabled
+
+    account_level {
+      activity_metrics {
+        enabled = var.storage_lens.account_level.activity_metrics_enabled
+      }
+
+      bucket_level {
+        activity_metrics {
+          enabled = var.storage_lens.account_level.bucket_level_activity_metrics_enabled
+        }
+      }
+    }
+
+    include {
+      buckets = [aws_s3_bucket.this[0].arn]
+    }
+  }
+
+  tags = local.computed_tags
+}
+
+# Key policy for the created key. Without extra key users the AWS default policy applies
+data "aws_iam_policy_document" "kms_key" {
+  count = local.create_kms_key && length(var.kms_key_user_principals) > 0 ? 1 : 0
+
+  # Same as the default key policy, so IAM policies in this account keep working
+  statement {
+    sid       = "EnableIAMUserPermissions"
+    effect    = "Allow"
+    actions   = ["kms:*"]
+    resources = ["*"]
+
+    principals {
+      type        = "AWS"
+      identifiers = ["arn:aws:iam::${data.aws_caller_identity.current.account_id}:root"]
+    }
+  }
+
+  statement {
+    sid    = "AllowKeyUse"
+    effect = "Allow"
+    actions = [
+      "kms:Encrypt",
+      "kms:Decrypt",
+      "kms:ReEncrypt*",
+      "kms:GenerateDataKey*",
+      "kms:DescribeKey"
+    ]
+    resources = ["*"]
+
+    principals {
+      type        = "AWS"
+      identifiers = var.kms_key_user_principals
+    }
+  }
+}
+
+# S3 Bucket KMS Key
+resource "aws_kms_key" "this" {
+  count                   = local.create_kms_key ? 1 : 0
+  description             = "SSE-KMS key for S3 bucket ${aws_s3_bucket.this[0].bucket}"
+  deletion_window_in_days = var.kms_key_deletion_window_in_days
+  enable_key_rotation     = var.kms_key_enable_rotation
+  policy                  = try(data.aws_iam_policy_document.kms_key[0].json, null)
+
+  tags = merge(local.computed_tags, var.kms_key_tags)
+}
+
+resource "aws_kms_alias" "this" {
+  count         = local.create_kms_key ? 1 : 0
+  name          = var.created_kms_key_alias != null ? var.created_kms_key_alias : "alias/${aws_s3_bucket.this[0].bucket}"
+  target_key_id = aws_kms_key.this[0].key_id
+}
+
+# Existing KMS key referenced by alias
+data "aws_kms_key" "lookup" {
+  count  = var.create && var.kms_key_alias != null ? 1 : 0
+  key_id = var.kms_key_alias
+}
@@ -2,27 +2,49 @@
 
 output "bucket_id" {
   description = "The name of the bucket"
-  value       = aws_s3_bucket.this.id
+  value       = try(aws_s3_bucket.this[0].id, null)
+}
+
+output "bucket_name" {
+  description = "The name of the bucket, including the suffix generated when bucket_prefix is used"
+  value       = try(aws_s3_bucket.this[0].bucket, null)
 }
 
 output "bucket_arn" {
   description = "The ARN of the bucket"
-  value       = aws_s3_bucket.this.arn
+  value       = try(aws_s3_bucket.this[0].arn, null)
 }
 
 output "bucket_domain_name" {
   description = "The bucket domain name"
-  value       = aws_s3_bucket.this.bucket_domain_name
+  value       = try(aws_s3_bucket.this[0].bucket_domain_name, null)
 }
 
 output "bucket_regional_domain_name" {
   description = "The bucket region-specific domain name"
-  value       = aws_s3_bucket.this.bucket_regional_domain_name
+  value       = try(aws_s3_bucket.this[0].bucket_regional_domain_name, null)
+}
+
+output "bucket_hosted_zone_id" {
+  description = "The Route 53 hosted zone ID for the bucket's region, for alias records"
+  value       = try(aws_s3_bucket.this[0].hosted_zone_id, null)
 }
 
 output "bucket_region" {
   description = "The AWS region this bucket resides in"
-  value       = aws_s3_bucket.this.region
+  value       = try(aws_s3_bucket.this[0].region, null)
+}
+
+output "bucket" {
+  description = "The bucket's id, arn, domain_name, regional_domain_name, hosted_zone_id and region in one object, or null when the bucket is not created"
+  value = var.create ? {
+    id                   = aws_s3_bucket.this[0].id
+    arn                  = aws_s3_bucket.this[0].arn
+    domain_name          = aws_s3_bucket.this[0].bucket_domain_name
+    regional_domain_name = aws_s3_bucket.this[0].bucket_regional_domain_name
+    hosted_zone_id       = aws_s3_bucket.this[0].hosted_zone_id
+    region               = aws_s3_bucket.this[0].region
+  } : null
 }
 
 output "bucket_url" {
@@ -37,7 +59,17 @@ output "bucket_website_endpoint" {
 
 output "bucket_website_domain" {
   description = "The domain of the website endpoint, if the bucket is configured with a website"
-  value       = try(aws_s3_bucket_website_configuration.this[0].website_domain, null)
+  value       = local.website_domain
+}
+
+output "website_domain" {
+  description = "The website domain to use as a Route 53 alias target, or null when the website is not enabled"
+  value       = local.website_domain
+}
+
+output "website_hosted_zone_id" {
+  description = "The Route 53 hosted zone ID of the website endpoint, or null when the website is not enabled"
+  value       = length(aws_s3_bucket_website_configuration.this) > 0 ? aws_s3_bucket.this[0].hosted_zone_id : null
 }
 
 output "bucket_website_redirect_all_requests_to" {
@@ -51,39 +83,39 @@ output "bucket_website_routing_rules" {
 }
 
 output "bucket_versioning_status" {
-  description = "The versioning state of the bucket"
-  value       = aws_s3_bucket_versioning.this.versioning_configuration[0].status
+  description = "The versioning state of the bucket: Enabled, Suspended or Disabled. Disabled when the bucket is not created"
+  value       = local.versioning_status
 }
 
 output "bucket_encryption_algorithm" {
   description = "The server-side encryption algorithm used"
-  value       = aws_s3_bucket_server_side_encryption_configuration.this.rule[0].apply_server_side_encryption_by_default[0].sse_algorithm
+  value       = try(aws_s3_bucket_server_side_encryption_configuration.this[0].rule[0].apply_server_side_encryption_by_default[0].sse_algorithm, null)
 }
 
 output "bucket_kms_key_id" {
   description = "The KMS key ID used for encryption"
-  value       = aws_s3_bucket_server_side_encryption_configuration.this.rule[0].apply_server_side_encryption_by_default[0].kms_master_key_id
+  value       = try(aws_s3_bucket_server_side_encryption_configuration.this[0].rule[0].apply_server_side_encryption_by_default[0].kms_master_key_id, null)
   sensitive   = true
 }
 
 output "bucket_key_enabled" {
   description = "Whether bucket keys are enabled for SSE-KMS"
-  value       = aws_s3_bucket_server_side_encryption_configuration.this.rule[0].bucket_key_enabled
+  value       = try(aws_s3_bucket_server_side_encryption_configuration.this[0].rule[0].bucket_key_enabled, null)
 }
 
 output "bucket_public_access_block_configuration" {
-  description = "The public access block configuration"
-  value = {
-    block_public_acls       = aws_s3_bucket_public_access_block.this.block_public_acls
-    block_public_policy     = aws_s3_bucket_public_access_block.this.block_public_policy
-    ignore_public_acls      = aws_s3_bucket_public_access_block.this.ignore_public_acls
-    restrict_public_buckets = aws_s3_bucket_public_access_block.this.restrict_public_buckets
-  }
+  description = "The public access block configuration, or null when the module does not manage it"
+  value = length(aws_s3_bucket_public_access_block.this) > 0 ? {
+    block_public_acls       = aws_s3_bucket_public_access_block.this[0].block_public_acls
+    block_public_policy     = aws_s3_bucket_public_access_block.this[0].block_public_policy
+    ignore_public_acls      = aws_s3_bucket_public_access_block.this[0].ignore_public_acls
+    restrict_public_buckets = aws_s3_bucket_public_access_block.this[0].restrict_public_buckets
+  } : null
 }
 
 output "bucket_ownership_controls" {
   description = "The bucket ownership controls"
-  value       = aws_s3_bucket_ownership_controls.this.rule[0].object_ownership
+  value       = try(aws_s3_bucket_ownership_controls.this[0].rule[0].object_ownership, null)
 }
 
 output "bucket_acl" {
@@ -115,16 +147,27 @@ output "bucket_policy" {
   value       = try(aws_s3_bucket_policy.this[0].policy, null)
 }
 
+output "bucket_policy_json" {
+  description = "The rendered policy document combining bucket_policy and every generated statement, or null when no policy is attached"
+  value       = try(data.aws_iam_policy_document.bucket_policy[0].json, null)
+}
+
 output "bucket_replication_configuration" {
   description = "The replication configuration of the bucket"
   value       = try(aws_s3_bucket_replication_configuration.this[0].rule, [])
 }
 
+output "bucket_replication_role_arn" {
+  description = "The ARN of the IAM role used for replication, whether provided or created by the module"
+  value       = local.replication_role_arn
+}
+
 output "bucket_intelligent_tiering_configurations" {
   description = "The intelligent tiering configurations of the bucket"
   value       = [for config in aws_s3_bucket_intelligent_tiering_configuration.this : {
-    id   = config.id
-    name = config.name
+    id     = config.id
+    name   = config.name
+    status = config.status
   }]
 }
 
@@ -135,5 +178,131 @@ output "bucket_object_lock_configuration" {
 
 output "bucket_tags" {
   description = "A mapping of tags assigned to the bucket"
-  value       = aws_s3_bucket.this.tags
-} 
\ No newline at end of file
+  value       = try(aws_s3_bucket.this[0].tags, null)
+}
+
+output "effective_tags" {
+  description = "The merged tags applied to every taggable resource the module creates"
+  value       = local.computed_tags
+}
+
+output "logging_enabled" {
+  description = "Whether server access logging is enabled for the bucket"
+  value       = var.create && var.logging != null
+}
+
+output "bucket_logging_target" {
+  description = "The target bucket and prefix for server access logs, if logging is enabled"
+  value = try({
+    target_bucket = aws_s3_bucket_logging.this[0].target_bucket
+    target_prefix = aws_s3_bucket_logging.this[0].target_prefix
+  }, null)
+}
+
+output "bucket_inventory_configurations" {
+  description = "The names of the inventory configurations of the bucket"
+  value       = keys(aws_s3_bucket_inventory.this)
+}
+
+output "bucket_analytics_configurations" {
+  description = "The names of the storage class analysis configurations of the bucket"
+  value       = keys(aws_s3_bucket_analytics_configuration.this)
+}
+
+output "metrics_configuration_ids" {
+  description = "The IDs of the CloudWatch request metrics configurations of the bucket"
+  value       = keys(aws_s3_bucket_metric.this)
+}
+
+output "request_alarm_arns" {
+  description = "The ARNs of the request error alarms, keyed by <metrics configuration id>-<4xx|5xx>"
+  value       = { for key, alarm in aws_cloudwatch_metric_alarm.request_errors : key => alarm.arn }
+}
+
+output "replication_latency_alarm_arns" {
+  description = "The ARNs of the replication latency alarms, keyed by replication rule id"
+  value       = { for key, alarm in aws_cloudwatch_metric_alarm.replication_latency : key => alarm.arn }
+}
+
+output "acceleration_endpoint" {
+  description = "The transfer acceleration endpoint of the bucket, if acceleration is enabled"
+  value       = var.create && var.acceleration_status == "Enabled" ? "${aws_s3_bucket.this[0].bucket}.s3-accelerate.amazonaws.com" : null
+}
+
+output "acceleration_status" {
+  description = "The transfer acceleration status of the bucket (Enabled or Suspended), or null when acceleration is not configured"
+  value       = try(aws_s3_bucket_accelerate_configuration.this[0].status, null)
+}
+
+output "request_payer" {
+  description = "Who pays for requests and data transfer on the bucket"
+  value       = var.create ? try(aws_s3_bucket_request_payment_configuration.this[0].payer, "BucketOwner") : null
+}
+
+output "kms_key_arn" {
+  description = "The ARN of the KMS key used for SSE-KMS, whether created by the module or supplied"
+  value       = local.kms_key_arn
+}
+
+output "kms_key_id" {
+  description = "The ID of the KMS key created by the module, or the supplied key"
+  value       = local.create_kms_key ? aws_kms_key.this[0].key_id : local.encryption.kms_master_key_id
+}
+
+output "storage_lens_configuration_arn" {
+  description = "The ARN of the Storage Lens configuration scoped to the bucket"
+  value       = try(aws_s3control_storage_lens_configuration.this[0].arn, null)
+}
+
+output "access_point_arns" {
+  description = "The ARNs of the access points, keyed by name"
+  value       = { for name, access_point in aws_s3_access_point.this : name => access_point.arn }
+}
+
+output "object_lambda_access_point_arns" {
+  description = "The ARNs of the Object Lambda access points, keyed by name"
+  value       = { for name, olap in aws_s3control_object_lambda_access_point.this : name => olap.arn }
+}
+
+output "object_lock_enabled" {
+  description = "Whether the bucket was created with object lock enabled. False when the bucket is not created"
+  value       = try(aws_s3_bucket.this[0].object_lock_enabled, false)
+}
+
+output "versioning_status" {
+  description = "The versioning state of the bucket: Enabled, Suspended or Disabled. Disabled when the bucket is not created"
+  value       = local.versioning_status
+}
+
+output "all_resource_arns" {
+  description = "The bucket ARN, its object wildcard, the created KMS key and the access point ARNs, for scoping IAM policies. Empty when the bucket is not created"
+  value = var.create ? concat(
+    [aws_s3_bucket.this[0].arn, "${aws_s3_bucket.this[0].arn}/*"],
+    aws_kms_key.this[*].arn,
+    [for access_point in aws_s3_access_point.this : access_point.arn],
+    [for olap in aws_s3control_object_lambda_access_point.this : olap.arn]
+  ) : []
+}
+
+output "feature_summary" {
+  description = "Which features are in effect, resolved from the created resources. Every flag is false when the bucket is not created"
+  value = {
+    versioning_enabled  = try(aws_s3_bucket_versioning.this[0].versioning_configuration[0].status, "Disabled") == "Enabled"
+    encryption_kms      = try(one(aws_s3_bucket_server_side_encryption_configuration.this[0].rule).apply_server_side_encryption_by_default[0].sse_algorithm, null) == "aws:kms"
+    replication_enabled = length(aws_s3_bucket_replication_configuration.this) > 0
+    logging_enabled     = length(aws_s3_bucket_logging.this) > 0
+    object_lock_enabled = try(aws_s3_bucket.this[0].object_lock_enabled, false)
+    public_access_fully_blocked = try(alltrue([
+      aws_s3_bucket_public_access_block.this[0].block_public_acls,
+      aws_s3_bucket_public_access_block.this[0].block_public_policy,
+      aws_s3_bucket_public_access_block.this[0].ignore_public_acls,
+      aws_s3_bucket_public_access_block.this[0].restrict_public_buckets,
+    ]), false)
+    ssl_enforced = var.create && local.enforce_ssl
+  }
+}
+
+output "required_destination_bucket_policies_json" {
+  description = "Bucket policy documents allowing S3 to deliver this bucket's inventory reports, analytics exports and access logs, keyed by destination bucket ARN. Attach each to its destination bucket"
+  value       = local.destination_bucket_policies
+}
@@ -0,0 +1,752 @@
+package test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3control"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/gruntwork-io/terratest/modules/retry"
+	test_structure "github.com/gruntwork-io/terratest/modules/test-structure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// defaultTestRegion is the region tests run in unless overridden
+const defaultTestRegion = "us-east-1"
+
+// defaultTestReplicaRegions are the candidate replica regions, in order of preference
+var defaultTestReplicaRegions = []string{"us-west-2", "us-east-2"}
+
+// GetTestRegion returns the region for a test. TERRATEST_REGION_<TEST NAME> (upper case, e.g.
+// TERRATEST_REGION_TESTS3BUCKETBASIC) overrides it for one test and TERRATEST_REGION for all tests
+func GetTestRegion(t *testing.T) string {
+	if region := os.Getenv("TERRATEST_REGION_" + strings.ToUpper(t.Name())); region != "" {
+		return region
+	}
+	if region := os.Getenv("TERRATEST_REGION"); region != "" {
+		return region
+	}
+	return defaultTestRegion
+}
+
+// GetTestReplicaRegion returns the region for the replica side of a cross-region test. It never matches
+// GetTestRegion. TERRATEST_REPLICA_REGION_<TEST NAME> and TERRATEST_REPLICA_REGION override it like the
+// source region overrides
+func GetTestReplicaRegion(t *testing.T) string {
+	region := GetTestRegion(t)
+
+	for _, name := range []string{"TERRATEST_REPLICA_REGION_" + strings.ToUpper(t.Name()), "TERRATEST_REPLICA_REGION"} {
+		if replicaRegion := os.Getenv(name); replicaRegion != "" {
+			if replicaRegion == region {
+				t.Fatalf("%s must differ from the test region %s", name, region)
+			}
+			return replicaRegion
+		}
+	}
+
+	for _, replicaRegion := range defaultTestReplicaRegions {
+		if replicaRegion != region {
+			return replicaRegion
+		}
+	}
+	return ""
+}
+
+// UniqueBucketName appends a random suffix to prefix so parallel tests never collide on bucket names
+func UniqueBucketName(prefix string) string {
+	return prefix + "-" + strings.ToLower(random.UniqueId())
+}
+
+// CopyExampleToTemp copies the module to a temporary folder and returns the path of the example in it,
+// so parallel tests using the same example do not share .terraform directories or state
+func CopyExampleToTemp(t *testing.T, example string) string {
+	return test_structure.CopyTerraformFolderToTemp(t, "..", "examples/"+example)
+}
+
+// GetS3BucketEncryption returns the default server-side encryption configuration of the bucket
+func GetS3BucketEncryption(t *testing.T, region string, bucket string) *s3.ServerSideEncryptionConfiguration {
+	client := aws.NewS3Client(t, region)
+
+	output, err := client.GetBucketEncryption(&s3.GetBucketEncryptionInput{
+		Bucket: awssdk.String(bucket),
+	})
+	require.NoError(t, err)
+
+	return output.ServerSideEncryptionConfiguration
+}
+
+// GetS3BucketPublicAccessBlock returns the public access block configuration of the bucket
+func GetS3BucketPublicAccessBlock(t *testing.T, region string, bucket string) *s3.PublicAccessBlockConfiguration {
+	client := aws.NewS3Client(t, region)
+
+	output, err := client.GetPublicAccessBlock(&s3.GetPublicAccessBlockInput{
+		Bucket: awssdk.String(bucket),
+	})
+	require.NoError(t, err)
+
+	return output.PublicAccessBlockConfiguration
+}
+
+// GetS3BucketWebsite returns the website configuration of the bucket
+func GetS3BucketWebsite(t *testing.T, region string, bucket string) *s3.GetBucketWebsiteOutput {
+	client := aws.NewS3Client(t, region)
+
+	output, err := client.GetBucketWebsite(&s3.GetBucketWebsiteInput{
+		Bucket: awssdk.String(bucket),
+	})
+	require.NoError(t, err)
+
+	return output
+}
+
+// GetS3BucketLifecycle returns the lifecycle rules configured on the bucket
+func GetS3BucketLifecycle(t *testing.T, region string, bucket string) []*s3.LifecycleRule {
+	client := aws.NewS3Client(t, region)
+
+	output, err := client.GetBucketLifecycleConfiguration(&s3.GetBucketLifecycleConfigurationInput{
+		Bucket: awssdk.String(bucket),
+	})
+	require.NoError(t, err)
+
+	return output.Rules
+}
+
+// LifecycleTransition is a current version transition of a lifecycle rule
+type LifecycleTransition struct {
+	Days         int64
+	StorageClass string
+}
+
+// LifecycleRuleDetails is the subset of a lifecycle rule tests assert on. ExpirationDays is 0 when the
+// rule does not expire current versions by age
+type LifecycleRuleDetails struct {
+	ID             string
+	Status         string
+	Transitions    []LifecycleTransition
+	ExpirationDays int64
+}
+
+// GetS3BucketLifecycleRules returns the lifecycle rules of the bucket with transitions sorted by days
+func GetS3BucketLifecycleRules(t *testing.T, region string, bucket string) []LifecycleRuleDetails {
+	var rules []LifecycleRuleDetails
+	for _, rule := range GetS3BucketLifecycle(t, region, bucket) {
+		details := LifecycleRuleDetails{
+			ID:     awssdk.StringValue(rule.ID),
+			Status: awssdk.StringValue(rule.Status),
+		}
+		for _, transition := range rule.Transitions {
+			details.Transitions = append(details.Transitions, LifecycleTransition{
+				Days:         awssdk.Int64Value(transition.Days),
+				StorageClass: awssdk.StringValue(transition.StorageClass),
+			})
+		}
+		sort.Slice(details.Transitions, func(i, j int) bool {
+			return details.Transitions[i].Days < details.Transitions[j].Days
+		})
+		if rule.Expiration != nil {
+			details.ExpirationDays = awssdk.Int64Value(rule.Expiration.Days)
+		}
+		rules = append(rules, details)
+	}
+
+	return rules
+}
+
+// GetS3BucketTransitionDefaultMinimumObjectSize returns the transition minimum object size of the lifecycle configuration.
+// The SDK does not model the setting yet, so it is read from the response header
+func GetS3BucketTransitionDefaultMinimumObjectSize(t *testing.T, region string, bucket string) string {
+	client := aws.NewS3Client(t, region)
+
+	req, _ := client.GetBucketLifecycleConfigurationRequest(&s3.GetBucketLifecycleConfigurationInput{
+		Bucket: awssdk.String(bucket),
+	})
+	require.NoError(t, req.Send())
+
+	return req.HTTPResponse.Header.Get("x-amz-transition-default-minimum-object-size")
+}
+
+// GetS3BucketObjectLockConfiguration returns the object lock configuration of the bucket
+func GetS3BucketObjectLockConfiguration(t *testing.T, region string, bucket string) *s3.ObjectLockConfiguration {
+	client := aws.NewS3Client(t, region)
+
+	output, err := client.GetObjectLockConfiguration(&s3.GetObjectLockConfigurationInput{
+		Bucket: awssdk.String(bucket),
+	})
+	require.NoError(t, err)
+
+	return output.ObjectLockConfiguration
+}
+
+// GetS3BucketReplication returns the replication configuration of the bucket
+func GetS3BucketReplication(t *testing.T, region string, bucket string) *s3.ReplicationConfiguration {
+	client := aws.NewS3Client(t, region)
+
+	output, err := client.GetBucketReplication(&s3.GetBucketReplicationInput{
+		Bucket: awssdk.String(bucket),
+	})
+	require.NoError(t, err)
+
+	return output.ReplicationConfiguration
+}
+
+// PutS3ObjectContents uploads body to the given key in the bucket
+func PutS3ObjectContents(t *testing.T, region string, bucket string, key string, body string) {
+	client := aws.NewS3Client(t, region)
+
+	_, err := client.PutObject(&s3.PutObjectInput{
+		Bucket: awssdk.String(bucket),
+		Key:    awssdk.String(key),
+		Body:   strings.NewReader(body),
+	})
+	require.NoError(t, err)
+}
+
+// PutS3ObjectContentsWithTagging uploads body to the given key with a URL-encoded tag set such as "a=1&b=2"
+func PutS3ObjectContentsWithTagging(t *testing.T, region string, bucket string, key string, body string, tagging string) {
+	client := aws.NewS3Client(t, region)
+
+	_, err := client.PutObject(&s3.PutObjectInput{
+		Bucket:  awssdk.String(bucket),
+		Key:     awssdk.String(key),
+		Body:    strings.NewReader(body),
+		Tagging: awssdk.String(tagging),
+	})
+	require.NoError(t, err)
+}
+
+// PutS3ObjectVersionsWithDeleteMarker uploads count versions of the given key and then deletes it, leaving
+// the versions behind a delete marker in a versioned bucket
+func PutS3ObjectVersionsWithDeleteMarker(t *testing.T, region string, bucket string, key string, count int) {
+	for i := 1; i <= count; i++ {
+		PutS3ObjectContents(t, region, bucket, key, fmt.Sprintf("version %d", i))
+	}
+
+	client := aws.NewS3Client(t, region)
+
+	output, err := client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: awssdk.String(bucket),
+		Key:    awssdk.String(key),
+	})
+	require.NoError(t, err)
+	require.True(t, awssdk.BoolValue(output.DeleteMarker))
+}
+
+// CountS3ObjectVersions returns the number of object versions and delete markers in the bucket
+func CountS3ObjectVersions(t *testing.T, region string, bucket string) (int, int) {
+	client := aws.NewS3Client(t, region)
+
+	versions, deleteMarkers := 0, 0
+	err := client.ListObjectVersionsPages(&s3.ListObjectVersionsInput{
+		Bucket: awssdk.String(bucket),
+	}, func(page *s3.ListObjectVersionsOutput, lastPage bool) bool {
+		versions += len(page.Versions)
+		deleteMarkers += len(page.DeleteMarkers)
+		return true
+	})
+	require.NoError(t, err)
+
+	return versions, deleteMarkers
+}
+
+// PutS3ObjectContentsWithEncryptionE uploads body to the given key, sending the x-amz-server-side-encryption
+// header only when algorithm is not empty, and returns any error from S3
+func PutS3ObjectContentsWithEncryptionE(t *testing.T, region string, bucket string, key string, body string, algorithm string) error {
+	client := aws.NewS3Client(t, region)
+
+	input := &s3.PutObjectInput{
+		Bucket: awssdk.String(bucket),
+		Key:    awssdk.String(key),
+		Body:   strings.NewReader(body),
+	}
+	if algorithm != "" {
+		input.ServerSideEncryption = awssdk.String(algorithm)
+	}
+
+	_, err := client.PutObject(input)
+	return err
+}
+
+// PutAndGetObject uploads body to the given key without encryption headers, reads it back and returns the
+// response alongside the body that was read. The response carries the encryption S3 applied to the object
+func PutAndGetObject(t *testing.T, region string, bucket string, key string, body string) (*s3.GetObjectOutput, string) {
+	PutS3ObjectContents(t, region, bucket, key, body)
+
+	client := aws.NewS3Client(t, region)
+
+	output, err := client.GetObject(&s3.GetObjectInput{
+		Bucket: awssdk.String(bucket),
+		Key:    awssdk.String(key),
+	})
+	require.NoError(t, err)
+	defer output.Body.Close()
+
+	contents, err := io.ReadAll(output.Body)
+	require.NoError(t, err)
+
+	return output, string(contents)
+}
+
+// GetS3ObjectReplicationStatus returns the replication status of the given object, or an empty string when it is not replicated
+func GetS3ObjectReplicationStatus(t *testing.T, region string, bucket string, key string) string {
+	client := aws.NewS3Client(t, region)
+
+	output, err := client.HeadObject(&s3.HeadObjectInput{
+		Bucket: awssdk.String(bucket),
+		Key:    awssdk.String(key),
+	})
+	require.NoError(t, err)
+
+	return awssdk.StringValue(output.ReplicationStatus)
+}
+
+// GetS3BucketOwnershipControls returns the object ownership setting of the bucket
+func GetS3BucketOwnershipControls(t *testing.T, region string, bucket string) string {
+	client := aws.NewS3Client(t, region)
+
+	output, err := client.GetBucketOwnershipControls(&s3.GetBucketOwnershipControlsInput{
+		Bucket: awssdk.String(bucket),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, output.OwnershipControls.Rules)
+
+	return awssdk.StringValue(output.OwnershipControls.Rules[0].ObjectOwnership)
+}
+
+// GetS3BucketAcl returns the ACL of the bucket
+func GetS3BucketAcl(t *testing.T, region string, bucket string) *s3.GetBucketAclOutput {
+	client := aws.NewS3Client(t, region)
+
+	output, err := client.GetBucketAcl(&s3.GetBucketAclInput{
+		Bucket: awssdk.String(bucket),
+	})
+	require.NoError(t, err)
+
+	return output
+}
+
+// GetS3BucketCors returns the CORS rules configured on the bucket
+func GetS3BucketCors(t *testing.T, region string, bucket string) []*s3.CORSRule {
+	client := aws.NewS3Client(t, region)
+
+	output, err := client.GetBucketCors(&s3.GetBucketCorsInput{
+		Bucket: awssdk.String(bucket),
+	})
+	require.NoError(t, err)
+
+	return output.CORSRules
+}
+
+// GetS3BucketNotification returns the notification configuration of the bucket
+func GetS3BucketNotification(t *testing.T, region string, bucket string) *s3.NotificationConfiguration {
+	client := aws.NewS3Client(t, region)
+
+	output, err := client.GetBucketNotificationConfiguration(&s3.GetBucketNotificationConfigurationRequest{
+		Bucket: awssdk.String(bucket),
+	})
+	require.NoError(t, err)
+
+	return output
+}
+
+// GetS3BucketIntelligentTiering returns the intelligent tiering configuration with the given ID
+func GetS3BucketIntelligentTiering(t *testing.T, region string, bucket string, id string) *s3.IntelligentTieringConfiguration {
+	client := aws.NewS3Client(t, region)
+
+	output, err := client.GetBucketIntelligentTieringConfiguration(&s3.GetBucketIntelligentTieringConfigurationInput{
+		Bucket: awssdk.String(bucket),
+		Id:     awssdk.String(id),
+	})
+	require.NoError(t, err)
+
+	return output.IntelligentTieringConfiguration
+}
+
+// GetS3BucketInventory returns the inventory configuration with the given ID
+func GetS3BucketInventory(t *testing.T, region string, bucket string, id string) *s3.InventoryConfiguration {
+	client := aws.NewS3Client(t, region)
+
+	output, err := client.GetBucketInventoryConfiguration(&s3.GetBucketInventoryConfigurationInput{
+		Bucket: awssdk.String(bucket),
+		Id:     awssdk.String(id),
+	})
+	require.NoError(t, err)
+
+	return output.InventoryConfiguration
+}
+
+// GetS3BucketAnalytics returns the storage class analysis configuration with the given ID
+func GetS3BucketAnalytics(t *testing.T, region string, bucket string, id string) *s3.AnalyticsConfiguration {
+	client := aws.NewS3Client(t, region)
+
+	output, err := client.GetBucketAnalyticsConfiguration(&s3.GetBucketAnalyticsConfigurationInput{
+		Bucket: awssdk.String(bucket),
+		Id:     awssdk.String(id),
+	})
+	require.NoError(t, err)
+
+	return output.AnalyticsConfiguration
+}
+
+// GetS3BucketMetrics returns the request metrics configuration with the given ID
+func GetS3BucketMetrics(t *testing.T, region string, bucket string, id string) *s3.MetricsConfiguration {
+	client := aws.NewS3Client(t, region)
+
+	output, err := client.GetBucketMetricsConfiguration(&s3.GetBucketMetricsConfigurationInput{
+		Bucket: awssdk.String(bucket),
+		Id:     awssdk.String(id),
+	})
+	require.NoError(t, err)
+
+	return output.MetricsConfiguration
+}
+
+// GetCloudWatchMetricAlarm returns the metric alarm with the given name
+func GetCloudWatchMetricAlarm(t *testing.T, region string, name string) *cloudwatch.MetricAlarm {
+	sess, err := aws.NewAuthenticatedSession(region)
+	require.NoError(t, err)
+
+	output, err := cloudwatch.New(sess).DescribeAlarms(&cloudwatch.DescribeAlarmsInput{
+		AlarmNames: []*string{awssdk.String(name)},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.MetricAlarms, 1)
+
+	return output.MetricAlarms[0]
+}
+
+// GetS3BucketAccelerateStatus returns the transfer acceleration status of the bucket
+func GetS3BucketAccelerateStatus(t *testing.T, region string, bucket string) string {
+	client := aws.NewS3Client(t, region)
+
+	output, err := client.GetBucketAccelerateConfiguration(&s3.GetBucketAccelerateConfigurationInput{
+		Bucket: awssdk.String(bucket),
+	})
+	require.NoError(t, err)
+
+	return awssdk.StringValue(output.Status)
+}
+
+// GetS3BucketLoggingKeyFormat returns the access log key format ("simple" or "partitioned") and, for
+// partitioned keys, the partition date source. The SDK does not model the setting yet, so the raw
+// response is decoded
+func GetS3BucketLoggingKeyFormat(t *testing.T, region string, bucket string) (string, string) {
+	client := aws.NewS3Client(t, region)
+
+	req, _ := client.GetBucketLoggingRequest(&s3.GetBucketLoggingInput{
+		Bucket: awssdk.String(bucket),
+	})
+	req.Handlers.Unmarshal.Clear()
+	require.NoError(t, req.Send())
+	defer req.HTTPResponse.Body.Close()
+
+	body, err := io.ReadAll(req.HTTPResponse.Body)
+	require.NoError(t, err)
+
+	var status struct {
+		LoggingEnabled struct {
+			TargetObjectKeyFormat struct {
+				PartitionedPrefix *struct {
+					PartitionDateSource string
+				}
+			}
+		}
+	}
+	require.NoError(t, xml.Unmarshal(body, &status))
+
+	if partitioned := status.LoggingEnabled.TargetObjectKeyFormat.PartitionedPrefix; partitioned != nil {
+		return "partitioned", partitioned.PartitionDateSource
+	}
+	return "simple", ""
+}
+
+// GetS3BucketRegion returns the region the bucket was created in
+func GetS3BucketRegion(t *testing.T, region string, bucket string) string {
+	client := aws.NewS3Client(t, region)
+
+	output, err := client.GetBucketLocation(&s3.GetBucketLocationInput{
+		Bucket: awssdk.String(bucket),
+	})
+	require.NoError(t, err)
+
+	// Buckets in us-east-1 report an empty location constraint
+	if awssdk.StringValue(output.LocationConstraint) == "" {
+		return "us-east-1"
+	}
+
+	return awssdk.StringValue(output.LocationConstraint)
+}
+
+// GetS3BucketRequestPayer returns who pays for requests on the bucket
+func GetS3BucketRequestPayer(t *testing.T, region string, bucket string) string {
+	client := aws.NewS3Client(t, region)
+
+	output, err := client.GetBucketRequestPayment(&s3.GetBucketRequestPaymentInput{
+		Bucket: awssdk.String(bucket),
+	})
+	require.NoError(t, err)
+
+	return awssdk.StringValue(output.Payer)
+}
+
+// CreateReplicationRole creates an IAM role S3 can assume to replicate from the source bucket to the
+// destination bucket and returns its ARN
+func CreateReplicationRole(t *testing.T, region string, name string, sourceBucket string, destinationBucket string) string {
+	client := aws.NewIamClient(t, region)
+
+	output, err := client.CreateRole(&iam.CreateRoleInput{
+		RoleName:                 awssdk.String(name),
+		AssumeRolePolicyDocument: awssdk.String(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"s3.amazonaws.com"},"Action":"sts:AssumeRole"}]}`),
+	})
+	require.NoError(t, err)
+
+	policy := fmt.Sprintf(`{"Version":"2012-10-17","Statement":[`+
+		`{"Effect":"Allow","Action":["s3:GetReplicationConfiguration","s3:ListBucket"],"Resource":"arn:aws:s3:::%[1]s"},`+
+		`{"Effect":"Allow","Action":["s3:GetObjectVersionForReplication","s3:GetObjectVersionAcl","s3:GetObjectVersionTagging"],"Resource":"arn:aws:s3:::%[1]s/*"},`+
+		`{"Effect":"Allow","Action":["s3:ReplicateObject","s3:ReplicateDelete","s3:ReplicateTags"],"Resource":"arn:aws:s3:::%[2]s/*"}]}`,
+		sourceBucket, destinationBucket)
+
+	_, err = client.PutRolePolicy(&iam.PutRolePolicyInput{
+		RoleName:       awssdk.String(name),
+		PolicyName:     awssdk.String("s3-replication"),
+		PolicyDocument: awssdk.String(policy),
+	})
+	require.NoError(t, err)
+
+	return awssdk.StringValue(output.Role.Arn)
+}
+
+// DeleteReplicationRole deletes a role created by CreateReplicationRole
+func DeleteReplicationRole(t *testing.T, region string, name string) {
+	client := aws.NewIamClient(t, region)
+
+	_, err := client.DeleteRolePolicy(&iam.DeleteRolePolicyInput{
+		RoleName:   awssdk.String(name),
+		PolicyName: awssdk.String("s3-replication"),
+	})
+	require.NoError(t, err)
+
+	_, err = client.DeleteRole(&iam.DeleteRoleInput{
+		RoleName: awssdk.String(name),
+	})
+	require.NoError(t, err)
+}
+
+// CreateKMSKey creates a symmetric KMS key with the default key policy and returns its ARN
+func CreateKMSKey(t *testing.T, region string, description string) string {
+	client := aws.NewKmsClient(t, region)
+
+	output, err := client.CreateKey(&kms.CreateKeyInput{
+		Description: awssdk.String(description),
+	})
+	require.NoError(t, err)
+
+	return awssdk.StringValue(output.KeyMetadata.Arn)
+}
+
+// CreateKMSAlias points the alias, such as alias/test, at the given KMS key
+func CreateKMSAlias(t *testing.T, region string, alias string, keyArn string) {
+	client := aws.NewKmsClient(t, region)
+
+	_, err := client.CreateAlias(&kms.CreateAliasInput{
+		AliasName:   awssdk.String(alias),
+		TargetKeyId: awssdk.String(keyArn),
+	})
+	require.NoError(t, err)
+}
+
+// DeleteKMSAlias deletes an alias created by CreateKMSAlias
+func DeleteKMSAlias(t *testing.T, region string, alias string) {
+	client := aws.NewKmsClient(t, region)
+
+	_, err := client.DeleteAlias(&kms.DeleteAliasInput{
+		AliasName: awssdk.String(alias),
+	})
+	require.NoError(t, err)
+}
+
+// ScheduleKMSKeyDeletion schedules the KMS key for deletion after the minimum waiting period
+func ScheduleKMSKeyDeletion(t *testing.T, region string, keyArn string) {
+	client := aws.NewKmsClient(t, region)
+
+	_, err := client.ScheduleKeyDeletion(&kms.ScheduleKeyDeletionInput{
+		KeyId:               awssdk.String(keyArn),
+		PendingWindowInDays: awssdk.Int64(7),
+	})
+	require.NoError(t, err)
+}
+
+// GetKMSKeyMetadata returns the metadata of the given KMS key
+func GetKMSKeyMetadata(t *testing.T, region string, keyArn string) *kms.KeyMetadata {
+	client := aws.NewKmsClient(t, region)
+
+	output, err := client.DescribeKey(&kms.DescribeKeyInput{
+		KeyId: awssdk.String(keyArn),
+	})
+	require.NoError(t, err)
+
+	return output.KeyMetadata
+}
+
+// GetKMSKeyRotationEnabled returns whether automatic rotation is enabled for the given KMS key
+func GetKMSKeyRotationEnabled(t *testing.T, region string, keyArn string) bool {
+	client := aws.NewKmsClient(t, region)
+
+	output, err := client.GetKeyRotationStatus(&kms.GetKeyRotationStatusInput{
+		KeyId: awssdk.String(keyArn),
+	})
+	require.NoError(t, err)
+
+	return awssdk.BoolValue(output.KeyRotationEnabled)
+}
+
+// GetKMSKeyTags returns the tags of the given KMS key as a map
+func GetKMSKeyTags(t *testing.T, region string, keyArn string) map[string]string {
+	client := aws.NewKmsClient(t, region)
+
+	output, err := client.ListResourceTags(&kms.ListResourceTagsInput{
+		KeyId: awssdk.String(keyArn),
+	})
+	require.NoError(t, err)
+
+	tags := map[string]string{}
+	for _, tag := range output.Tags {
+		tags[awssdk.StringValue(tag.TagKey)] = awssdk.StringValue(tag.TagValue)
+	}
+
+	return tags
+}
+
+// GetKMSKeyPolicy returns the default key policy document of the given KMS key
+func GetKMSKeyPolicy(t *testing.T, region string, keyArn string) string {
+	client := aws.NewKmsClient(t, region)
+
+	output, err := client.GetKeyPolicy(&kms.GetKeyPolicyInput{
+		KeyId:      awssdk.String(keyArn),
+		PolicyName: awssdk.String("default"),
+	})
+	require.NoError(t, err)
+
+	return awssdk.StringValue(output.Policy)
+}
+
+// GetS3ObjectKMSKeyId returns the KMS key used to encrypt the given object
+func GetS3ObjectKMSKeyId(t *testing.T, region string, bucket string, key string) string {
+	client := aws.NewS3Client(t, region)
+
+	output, err := client.HeadObject(&s3.HeadObjectInput{
+		Bucket: awssdk.String(bucket),
+		Key:    awssdk.String(key),
+	})
+	require.NoError(t, err)
+
+	return awssdk.StringValue(output.SSEKMSKeyId)
+}
+
+// AssertS3BucketTags asserts the bucket carries every expected tag. Tagging is eventually consistent,
+// so it retries for up to 30 seconds before failing
+func AssertS3BucketTags(t *testing.T, region string, bucket string, expected map[string]string) {
+	client := aws.NewS3Client(t, region)
+
+	_, err := retry.DoWithRetryE(t, fmt.Sprintf("Verify tags of bucket %s", bucket), 6, 5*time.Second, func() (string, error) {
+		output, err := client.GetBucketTagging(&s3.GetBucketTaggingInput{
+			Bucket: awssdk.String(bucket),
+		})
+		if err != nil {
+			return "", err
+		}
+
+		actual := map[string]string{}
+		for _, tag := range output.TagSet {
+			actual[awssdk.StringValue(tag.Key)] = awssdk.StringValue(tag.Value)
+		}
+		for key, value := range expected {
+			if actual[key] != value {
+				return "", fmt.Errorf("tag %s is %q, expected %q", key, actual[key], value)
+			}
+		}
+
+		return "", nil
+	})
+	assert.NoError(t, err)
+}
+
+// eventuallyBucketReady waits until the bucket exists and its encryption and versioning configuration
+// can be read. S3 is eventually consistent, so these calls can fail for a short time after apply
+func eventuallyBucketReady(t *testing.T, region string, bucket string) {
+	client := aws.NewS3Client(t, region)
+
+	retry.DoWithRetry(t, fmt.Sprintf("Wait for bucket %s to be ready", bucket), 12, 5*time.Second, func() (string, error) {
+		if err := aws.AssertS3BucketExistsE(t, region, bucket); err != nil {
+			return "", err
+		}
+
+		if _, err := client.GetBucketEncryption(&s3.GetBucketEncryptionInput{
+			Bucket: awssdk.String(bucket),
+		}); err != nil {
+			return "", err
+		}
+
+		if _, err := client.GetBucketVersioning(&s3.GetBucketVersioningInput{
+			Bucket: awssdk.String(bucket),
+		}); err != nil {
+			return "", err
+		}
+
+		return "", nil
+	})
+}
+
+// GetS3StorageLensConfiguration returns the Storage Lens configuration with the given ID in the account
+func GetS3StorageLensConfiguration(t *testing.T, region string, accountId string, configId string) *s3control.StorageLensConfiguration {
+	sess, err := aws.NewAuthenticatedSession(region)
+	require.NoError(t, err)
+
+	output, err := s3control.New(sess).GetStorageLensConfiguration(&s3control.GetStorageLensConfigurationInput{
+		AccountId: awssdk.String(accountId),
+		ConfigId:  awssdk.String(configId),
+	})
+	require.NoError(t, err)
+
+	return output.StorageLensConfiguration
+}
+
+// GetS3AccessPoint returns the access point with the given name in the account
+func GetS3AccessPoint(t *testing.T, region string, accountId string, name string) *s3control.GetAccessPointOutput {
+	sess, err := aws.NewAuthenticatedSession(region)
+	require.NoError(t, err)
+
+	output, err := s3control.New(sess).GetAccessPoint(&s3control.GetAccessPointInput{
+		AccountId: awssdk.String(accountId),
+		Name:      awssdk.String(name),
+	})
+	require.NoError(t, err)
+
+	return output
+}
+
+// GetS3ObjectLambdaAccessPointConfiguration returns the configuration of the Object Lambda access point with the given name
+func GetS3ObjectLambdaAccessPointConfiguration(t *testing.T, region string, accountId string, name string) *s3control.ObjectLambdaConfiguration {
+	sess, err := aws.NewAuthenticatedSession(region)
+	require.NoError(t, err)
+
+	output, err := s3control.New(sess).GetAccessPointConfigurationForObjectLambda(&s3control.GetAccessPointConfigurationForObjectLambdaInput{
+		AccountId: awssdk.String(accountId),
+		Name:      awssdk.String(name),
+	})
+	require.NoError(t, err)
+
+	return output.Configuration
+}
@@ -25,12 +25,12 @@ run "basic_s3_bucket_creation" {
   command = plan
 
   assert {
-    condition     = aws_s3_bucket.this.bucket == var.bucket_name
+    condition     = aws_s3_bucket.this[0].bucket == var.bucket_name
     error_message = "Bucket name should match expected value"
   }
 
   assert {
-    condition     = aws_s3_bucket_versioning.this.versioning_configuration[0].status == "Enabled"
+    condition     = aws_s3_bucket_versioning.this[0].versioning_configuration[0].status == "Enabled"
     error_message = "Versioning should be enabled by default"
   }
 }
@@ -40,18 +40,18 @@ run "security_configurations" {
   command = plan
 
   assert {
-    condition     = aws_s3_bucket_public_access_block.this.block_public_acls == true
+    condition     = aws_s3_bucket_public_access_block.this[0].block_public_acls == true
     error_message = "Public ACLs should be blocked by default"
   }
 
   assert {
-    condition     = aws_s3_bucket_server_side_encryption_configuration.this.rule[0].apply_server_side_encryption_by_default[0].sse_algorithm == "AES256"
+    condition     = aws_s3_bucket_server_side_encryption_configuration.this[0].rule[0].apply_server_side_encryption_by_default[0].sse_algorithm == "AES256"
     error_message = "Default encryption should be AES256"
   }
 
   assert {
-    condition     = aws_s3_bucket_ownership_controls.this.rule[0].object_ownership == "BucketOwnerPreferred"
-    error_message = "Object ownership should be BucketOwnerPreferred by default"
+    condition     = aws_s3_bucket_ownership_controls.this[0].rule[0].object_ownership == "BucketOwnerEnforced"
+    error_message = "Object ownership should be BucketOwnerEnforced by default"
   }
 }
 
@@ -1,28 +1,45 @@
 package test
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
-	"github.com/gruntwork-io/terratest/modules/terraform"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/gruntwork-io/terratest/modules/aws"
+	http_helper "github.com/gruntwork-io/terratest/modules/http-helper"
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestS3BucketBasic(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
 	// Configure Terraform options
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		// The path to where our Terraform code is located
-		TerraformDir: "../examples/basic",
+		TerraformDir: CopyExampleToTemp(t, "basic"),
 
 		// Variables to pass to our Terraform code using -var options
 		Vars: map[string]interface{}{
-			"bucket_name": "test-bucket-" + time.Now().Format("20060102150405"),
+			"region":           region,
+			"bucket_name":      UniqueBucketName("test-bucket"),
+			"object_ownership": "BucketOwnerPreferred",
 		},
 
 		// Environment variables to set when running Terraform
 		EnvVars: map[string]string{
-			"AWS_DEFAULT_REGION": "us-east-1",
+			"AWS_DEFAULT_REGION": region,
 		},
 	})
 
@@ -37,38 +54,83 @@ func TestS3BucketBasic(t *testing.T) {
 	bucketArn := terraform.Output(t, terraformOptions, "bucket_arn")
 
 	// Verify that the bucket exists
-	aws.AssertS3BucketExists(t, "us-east-1", bucketName)
+	eventuallyBucketReady(t, region, bucketName)
 
 	// Verify bucket properties
-	bucket := aws.GetS3BucketVersioning(t, "us-east-1", bucketName)
-	assert.Equal(t, "Enabled", bucket.Status)
+	versioning := aws.GetS3BucketVersioning(t, region, bucketName)
+	assert.Equal(t, "Enabled", versioning)
 
-	// Verify bucket encryption
-	encryption := aws.GetS3BucketEncryption(t, "us-east-1", bucketName)
-	assert.NotNil(t, encryption)
+	// Verify bucket encryption defaults to AES256
+	encryption := GetS3BucketEncryption(t, region, bucketName)
+	if assert.Len(t, encryption.Rules, 1) {
+		assert.Equal(t, "AES256", awssdk.StringValue(encryption.Rules[0].ApplyServerSideEncryptionByDefault.SSEAlgorithm))
+	}
 
 	// Verify bucket public access block
-	publicAccessBlock := aws.GetS3BucketPublicAccessBlock(t, "us-east-1", bucketName)
-	assert.True(t, publicAccessBlock.BlockPublicAcls)
-	assert.True(t, publicAccessBlock.BlockPublicPolicy)
-	assert.True(t, publicAccessBlock.IgnorePublicAcls)
-	assert.True(t, publicAccessBlock.RestrictPublicBuckets)
+	publicAccessBlock := GetS3BucketPublicAccessBlock(t, region, bucketName)
+	assert.True(t, awssdk.BoolValue(publicAccessBlock.BlockPublicAcls))
+	assert.True(t, awssdk.BoolValue(publicAccessBlock.BlockPublicPolicy))
+	assert.True(t, awssdk.BoolValue(publicAccessBlock.IgnorePublicAcls))
+	assert.True(t, awssdk.BoolValue(publicAccessBlock.RestrictPublicBuckets))
+
+	// Verify object ownership matches the requested setting
+	objectOwnership := GetS3BucketOwnershipControls(t, region, bucketName)
+	assert.Equal(t, "BucketOwnerPreferred", objectOwnership)
+	assert.Equal(t, "BucketOwnerPreferred", terraform.Output(t, terraformOptions, "bucket_ownership_controls"))
 
 	// Verify outputs
 	assert.NotEmpty(t, bucketName)
 	assert.NotEmpty(t, bucketArn)
 	assert.Contains(t, bucketArn, bucketName)
+
+	// Verify domain outputs used for CloudFront origins and Route 53 aliases
+	assert.NotEmpty(t, terraform.Output(t, terraformOptions, "bucket_domain_name"))
+	regionalDomainName := terraform.Output(t, terraformOptions, "bucket_regional_domain_name")
+	assert.Contains(t, regionalDomainName, bucketName)
+	assert.Contains(t, regionalDomainName, region)
+	assert.NotEmpty(t, terraform.Output(t, terraformOptions, "bucket_hosted_zone_id"))
+
+	// Verify object lock reports disabled when it was never enabled
+	assert.Equal(t, "false", terraform.Output(t, terraformOptions, "object_lock_enabled"))
+	assert.Equal(t, "Enabled", terraform.Output(t, terraformOptions, "versioning_status"))
+
+	// Verify the IAM scoping list holds the bucket and its objects only when no optional resources exist
+	assert.Equal(t, []string{bucketArn, bucketArn + "/*"}, terraform.OutputList(t, terraformOptions, "all_resource_arns"))
+
+	// Verify drift detection outputs report the defaults: no acceleration and the bucket owner paying
+	assert.NotContains(t, terraform.OutputAll(t, terraformOptions), "acceleration_status")
+	assert.Equal(t, "BucketOwner", terraform.Output(t, terraformOptions, "request_payer"))
+
+	// Verify the feature summary reflects the defaults
+	var featureSummary map[string]bool
+	terraform.OutputStruct(t, terraformOptions, "feature_summary", &featureSummary)
+	assert.Equal(t, map[string]bool{
+		"versioning_enabled":          true,
+		"encryption_kms":              false,
+		"replication_enabled":         false,
+		"logging_enabled":             false,
+		"object_lock_enabled":         false,
+		"public_access_fully_blocked": true,
+		"ssl_enforced":                false,
+	}, featureSummary)
 }
 
 func TestS3BucketWebsite(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
 	// Configure Terraform options
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
-		TerraformDir: "../examples/website",
+		TerraformDir: CopyExampleToTemp(t, "website"),
 		Vars: map[string]interface{}{
-			"bucket_name": "test-website-" + time.Now().Format("20060102150405"),
+			"region":         region,
+			"bucket_name":    UniqueBucketName("test-website"),
+			"index_document": "app.html",
+			"error_document": "app.html",
 		},
 		EnvVars: map[string]string{
-			"AWS_DEFAULT_REGION": "us-east-1",
+			"AWS_DEFAULT_REGION": region,
 		},
 	})
 
@@ -80,28 +142,49 @@ func TestS3BucketWebsite(t *testing.T) {
 
 	// Get outputs
 	websiteEndpoint := terraform.Output(t, terraformOptions, "website_endpoint")
+	websiteDomain := terraform.Output(t, terraformOptions, "website_domain")
+	websiteHostedZoneID := terraform.Output(t, terraformOptions, "website_hosted_zone_id")
 	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
 
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
 	// Verify website configuration
-	websiteConfig := aws.GetS3BucketWebsite(t, "us-east-1", bucketName)
+	websiteConfig := GetS3BucketWebsite(t, region, bucketName)
 	assert.NotNil(t, websiteConfig)
-	assert.Equal(t, "index.html", websiteConfig.IndexDocument)
-	assert.Equal(t, "error.html", websiteConfig.ErrorDocument)
+	assert.Equal(t, "app.html", awssdk.StringValue(websiteConfig.IndexDocument.Suffix))
+	assert.Equal(t, "app.html", awssdk.StringValue(websiteConfig.ErrorDocument.Key))
+
+	// Verify the routing rule redirecting old/ to new/
+	if assert.Len(t, websiteConfig.RoutingRules, 1) {
+		assert.Equal(t, "old/", awssdk.StringValue(websiteConfig.RoutingRules[0].Condition.KeyPrefixEquals))
+		assert.Equal(t, "new/", awssdk.StringValue(websiteConfig.RoutingRules[0].Redirect.ReplaceKeyPrefixWith))
+	}
 
 	// Verify website endpoint
 	assert.NotEmpty(t, websiteEndpoint)
 	assert.Contains(t, websiteEndpoint, bucketName)
+
+	// Verify the Route 53 alias target outputs are populated
+	assert.Contains(t, websiteDomain, "s3-website")
+	assert.NotContains(t, websiteDomain, bucketName)
+	assert.NotEmpty(t, websiteHostedZoneID)
 }
 
 func TestS3BucketDataLake(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
 	// Configure Terraform options
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
-		TerraformDir: "../examples/data-lake",
+		TerraformDir: CopyExampleToTemp(t, "data-lake"),
 		Vars: map[string]interface{}{
-			"bucket_name": "test-datalake-" + time.Now().Format("20060102150405"),
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-datalake"),
 		},
 		EnvVars: map[string]string{
-			"AWS_DEFAULT_REGION": "us-east-1",
+			"AWS_DEFAULT_REGION": region,
 		},
 	})
 
@@ -116,16 +199,4375 @@ func TestS3BucketDataLake(t *testing.T) {
 	encryptionAlgorithm := terraform.Output(t, terraformOptions, "data_lake_encryption_algorithm")
 
 	// Verify bucket exists
-	aws.AssertS3BucketExists(t, "us-east-1", bucketName)
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify object lock is reported as enabled for downstream retention decisions
+	assert.Equal(t, "true", terraform.Output(t, terraformOptions, "data_lake_object_lock_enabled"))
+
+	// Verify the feature summary reflects KMS encryption and object lock
+	var featureSummary map[string]bool
+	terraform.OutputStruct(t, terraformOptions, "data_lake_feature_summary", &featureSummary)
+	assert.Equal(t, map[string]bool{
+		"versioning_enabled":          true,
+		"encryption_kms":              true,
+		"replication_enabled":         false,
+		"logging_enabled":             false,
+		"object_lock_enabled":         true,
+		"public_access_fully_blocked": true,
+		"ssl_enforced":                false,
+	}, featureSummary)
 
 	// Verify encryption
 	assert.Equal(t, "aws:kms", encryptionAlgorithm)
+	encryption := GetS3BucketEncryption(t, region, bucketName)
+	if assert.NotEmpty(t, encryption.Rules) {
+		assert.True(t, awssdk.BoolValue(encryption.Rules[0].BucketKeyEnabled))
+	}
 
-	// Verify lifecycle configuration
-	lifecycleRules := aws.GetS3BucketLifecycle(t, "us-east-1", bucketName)
-	assert.NotEmpty(t, lifecycleRules)
+	// Verify every lifecycle rule keeps its exact transition days, storage classes and expiration
+	assert.ElementsMatch(t, []LifecycleRuleDetails{
+		{
+			ID:     "raw-data-transition",
+			Status: "Enabled",
+			Transitions: []LifecycleTransition{
+				{Days: 30, StorageClass: "STANDARD_IA"},
+				{Days: 90, StorageClass: "GLACIER"},
+				{Days: 365, StorageClass: "DEEP_ARCHIVE"},
+			},
+		},
+		{
+			ID:     "processed-data-transition",
+			Status: "Enabled",
+			Transitions: []LifecycleTransition{
+				{Days: 90, StorageClass: "STANDARD_IA"},
+				{Days: 180, StorageClass: "GLACIER"},
+			},
+		},
+		{
+			ID:             "temp-data-expiration",
+			Status:         "Enabled",
+			ExpirationDays: 7,
+		},
+		{
+			ID:     "incomplete-multipart-cleanup",
+			Status: "Enabled",
+		},
+	}, GetS3BucketLifecycleRules(t, region, bucketName))
 
 	// Verify object lock configuration
-	objectLockConfig := aws.GetS3BucketObjectLockConfiguration(t, "us-east-1", bucketName)
-	assert.NotNil(t, objectLockConfig)
-} 
\ No newline at end of file
+	objectLockConfig := GetS3BucketObjectLockConfiguration(t, region, bucketName)
+	if assert.NotNil(t, objectLockConfig) {
+		assert.Equal(t, "Enabled", awssdk.StringValue(objectLockConfig.ObjectLockEnabled))
+		if assert.NotNil(t, objectLockConfig.Rule) {
+			assert.Equal(t, "GOVERNANCE", awssdk.StringValue(objectLockConfig.Rule.DefaultRetention.Mode))
+			assert.Equal(t, int64(30), awssdk.Int64Value(objectLockConfig.Rule.DefaultRetention.Days))
+		}
+	}
+}
+
+func TestS3BucketReplication(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+	replicaRegion := GetTestReplicaRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "replication"),
+		Vars: map[string]interface{}{
+			"region":         region,
+			"replica_region": replicaRegion,
+			"bucket_name":    UniqueBucketName("test-replication"),
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	sourceBucketName := terraform.Output(t, terraformOptions, "source_bucket_name")
+	replicaBucketName := terraform.Output(t, terraformOptions, "replica_bucket_name")
+	replicationRoleArn := terraform.Output(t, terraformOptions, "replication_role_arn")
+
+	// Objects written during the test must be removed before the buckets can be destroyed
+	defer aws.EmptyS3Bucket(t, replicaRegion, replicaBucketName)
+	defer aws.EmptyS3Bucket(t, region, sourceBucketName)
+
+	// Verify both buckets exist
+	eventuallyBucketReady(t, region, sourceBucketName)
+	eventuallyBucketReady(t, replicaRegion, replicaBucketName)
+
+	// Verify the replication configuration uses the module-managed role
+	replication := GetS3BucketReplication(t, region, sourceBucketName)
+	assert.Equal(t, replicationRoleArn, awssdk.StringValue(replication.Role))
+	assert.Len(t, replication.Rules, 1)
+
+	// Verify an object written to the source is replicated to the destination
+	key := "replication-test.txt"
+	body := "replicated from " + sourceBucketName
+	PutS3ObjectContents(t, region, sourceBucketName, key, body)
+
+	replicated := retry.DoWithRetry(t, "Wait for object replication", 30, 10*time.Second, func() (string, error) {
+		return aws.GetS3ObjectContentsE(t, replicaRegion, replicaBucketName, key)
+	})
+	assert.Equal(t, body, replicated)
+}
+
+func TestS3BucketReplicationExistingRole(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+	replicaRegion := GetTestReplicaRegion(t)
+	bucketName := UniqueBucketName("test-replication-role")
+
+	// Create the role outside the module, as a central IAM team would
+	roleName := bucketName + "-role"
+	roleArn := CreateReplicationRole(t, region, roleName, bucketName, bucketName+"-replica")
+	defer DeleteReplicationRole(t, region, roleName)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "replication"),
+		Vars: map[string]interface{}{
+			"region":               region,
+			"replica_region":       replicaRegion,
+			"bucket_name":          bucketName,
+			"replication_role_arn": roleArn,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	sourceBucketName := terraform.Output(t, terraformOptions, "source_bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, sourceBucketName)
+
+	// Verify replication uses the supplied role
+	assert.Equal(t, roleArn, terraform.Output(t, terraformOptions, "replication_role_arn"))
+	replication := GetS3BucketReplication(t, region, sourceBucketName)
+	assert.Equal(t, roleArn, awssdk.StringValue(replication.Role))
+
+	// Verify the module did not create its own role or policy
+	state := terraform.RunTerraformCommand(t, terraformOptions, "state", "list")
+	assert.NotContains(t, state, "module.s3_source.aws_iam_role.replication")
+	assert.NotContains(t, state, "module.s3_source.aws_iam_role_policy.replication")
+}
+
+func TestS3BucketReplicationAcceleratedReplica(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+	replicaRegion := GetTestReplicaRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "replication"),
+		Vars: map[string]interface{}{
+			"region":                      region,
+			"replica_region":              replicaRegion,
+			"bucket_name":                 UniqueBucketName("test-accelerated-replica"),
+			"replica_acceleration_status": "Enabled",
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	sourceBucketName := terraform.Output(t, terraformOptions, "source_bucket_name")
+	replicaBucketName := terraform.Output(t, terraformOptions, "replica_bucket_name")
+	replicaBucketArn := terraform.Output(t, terraformOptions, "replica_bucket_arn")
+
+	// Wait until the buckets are ready before asserting on them
+	eventuallyBucketReady(t, region, sourceBucketName)
+	eventuallyBucketReady(t, replicaRegion, replicaBucketName)
+
+	// Verify the destination-only instance enables acceleration on the replica
+	assert.Equal(t, "Enabled", GetS3BucketAccelerateStatus(t, replicaRegion, replicaBucketName))
+	assert.Equal(t, "Enabled", terraform.Output(t, terraformOptions, "replica_acceleration_status"))
+	assert.Equal(t, replicaBucketName+".s3-accelerate.amazonaws.com", terraform.Output(t, terraformOptions, "replica_acceleration_endpoint"))
+
+	// Verify the accelerated bucket is still the replication destination
+	replication := GetS3BucketReplication(t, region, sourceBucketName)
+	if assert.Len(t, replication.Rules, 1) {
+		assert.Equal(t, replicaBucketArn, awssdk.StringValue(replication.Rules[0].Destination.Bucket))
+	}
+}
+
+func TestS3BucketAclGrants(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+	logDeliveryGroup := "http://acs.amazonaws.com/groups/s3/LogDelivery"
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":           region,
+			"bucket_name":      UniqueBucketName("test-acl-grants"),
+			"object_ownership": "BucketOwnerPreferred",
+			"grants": []map[string]interface{}{
+				{
+					"grantee_type": "Group",
+					"uri":          logDeliveryGroup,
+					"permission":   "WRITE",
+				},
+				{
+					"grantee_type": "Group",
+					"uri":          logDeliveryGroup,
+					"permission":   "READ_ACP",
+				},
+			},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the log delivery group can write logs and the owner keeps full control
+	acl := GetS3BucketAcl(t, region, bucketName)
+	ownerId := awssdk.StringValue(acl.Owner.ID)
+
+	var grants []string
+	for _, grant := range acl.Grants {
+		grantee := awssdk.StringValue(grant.Grantee.URI)
+		if grantee == "" {
+			grantee = awssdk.StringValue(grant.Grantee.ID)
+		}
+		grants = append(grants, grantee+" "+awssdk.StringValue(grant.Permission))
+	}
+	assert.ElementsMatch(t, []string{
+		ownerId + " FULL_CONTROL",
+		logDeliveryGroup + " WRITE",
+		logDeliveryGroup + " READ_ACP",
+	}, grants)
+}
+
+func TestS3BucketCors(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "cors"),
+		Vars: map[string]interface{}{
+			"region":          region,
+			"bucket_name":     UniqueBucketName("test-cors"),
+			"allowed_origins": []string{"https://app.example.com"},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify both CORS rules were applied
+	corsRules := GetS3BucketCors(t, region, bucketName)
+	assert.Len(t, corsRules, 2)
+
+	uploadRule := corsRules[0]
+	assert.Equal(t, "browser-uploads", awssdk.StringValue(uploadRule.ID))
+	assert.ElementsMatch(t, []string{"PUT", "POST"}, awssdk.StringValueSlice(uploadRule.AllowedMethods))
+	assert.Equal(t, []string{"https://app.example.com"}, awssdk.StringValueSlice(uploadRule.AllowedOrigins))
+	assert.Equal(t, []string{"ETag"}, awssdk.StringValueSlice(uploadRule.ExposeHeaders))
+	assert.Equal(t, int64(3000), awssdk.Int64Value(uploadRule.MaxAgeSeconds))
+
+	readRule := corsRules[1]
+	assert.Equal(t, "public-reads", awssdk.StringValue(readRule.ID))
+	assert.ElementsMatch(t, []string{"GET", "HEAD"}, awssdk.StringValueSlice(readRule.AllowedMethods))
+	assert.Equal(t, []string{"*"}, awssdk.StringValueSlice(readRule.AllowedOrigins))
+}
+
+func TestS3BucketLogging(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "logging"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-logging"),
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+	logBucketName := terraform.Output(t, terraformOptions, "log_bucket_name")
+	targetPrefix := terraform.Output(t, terraformOptions, "logging_target_prefix")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Access logs may be delivered before teardown
+	defer aws.EmptyS3Bucket(t, region, logBucketName)
+
+	// Verify the logging output
+	assert.Equal(t, "true", terraform.Output(t, terraformOptions, "logging_enabled"))
+
+	// Verify the logging configuration points at the secondary bucket
+	assert.Equal(t, logBucketName, aws.GetS3BucketLoggingTarget(t, region, bucketName))
+	assert.Equal(t, targetPrefix, aws.GetS3BucketLoggingTargetPrefix(t, region, bucketName))
+
+	// Verify log keys keep the default simple format
+	keyFormat, _ := GetS3BucketLoggingKeyFormat(t, region, bucketName)
+	assert.Equal(t, "simple", keyFormat)
+}
+
+func TestS3BucketLoggingPartitionedKeys(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "logging"),
+		Vars: map[string]interface{}{
+			"region":                   region,
+			"bucket_name":              UniqueBucketName("test-logging-partitioned"),
+			"target_object_key_format": "partitioned",
+			"partition_date_source":    "DeliveryTime",
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+	logBucketName := terraform.Output(t, terraformOptions, "log_bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Access logs may be delivered before teardown
+	defer aws.EmptyS3Bucket(t, region, logBucketName)
+
+	// Verify log keys are partitioned by delivery time
+	keyFormat, partitionDateSource := GetS3BucketLoggingKeyFormat(t, region, bucketName)
+	assert.Equal(t, "partitioned", keyFormat)
+	assert.Equal(t, "DeliveryTime", partitionDateSource)
+}
+
+func TestS3BucketLambdaNotification(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "notifications"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-notify"),
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+	lambdaFunctionArn := terraform.Output(t, terraformOptions, "lambda_function_arn")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the Lambda notification is configured on the bucket
+	notification := GetS3BucketNotification(t, region, bucketName)
+	assert.Len(t, notification.LambdaFunctionConfigurations, 1)
+
+	lambdaConfig := notification.LambdaFunctionConfigurations[0]
+	assert.Equal(t, lambdaFunctionArn, awssdk.StringValue(lambdaConfig.LambdaFunctionArn))
+	assert.Equal(t, []string{"s3:ObjectCreated:*"}, awssdk.StringValueSlice(lambdaConfig.Events))
+}
+
+func TestS3BucketSNSNotification(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "notifications"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-sns"),
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+	topicArn := terraform.Output(t, terraformOptions, "sns_topic_arn")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the SNS topic notification is configured on the bucket
+	notification := GetS3BucketNotification(t, region, bucketName)
+	assert.Len(t, notification.TopicConfigurations, 1)
+
+	topicConfig := notification.TopicConfigurations[0]
+	assert.Equal(t, topicArn, awssdk.StringValue(topicConfig.TopicArn))
+	assert.Equal(t, []string{"s3:ObjectRemoved:*"}, awssdk.StringValueSlice(topicConfig.Events))
+}
+
+func TestS3BucketSQSNotification(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "notifications"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-sqs"),
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+	queueArn := terraform.Output(t, terraformOptions, "sqs_queue_arn")
+	lambdaFunctionArn := terraform.Output(t, terraformOptions, "lambda_function_arn")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the SQS and Lambda destinations coexist in the single notification configuration
+	notification := GetS3BucketNotification(t, region, bucketName)
+	assert.Len(t, notification.QueueConfigurations, 1)
+	assert.Len(t, notification.LambdaFunctionConfigurations, 1)
+
+	assert.Equal(t, queueArn, awssdk.StringValue(notification.QueueConfigurations[0].QueueArn))
+	assert.Equal(t, lambdaFunctionArn, awssdk.StringValue(notification.LambdaFunctionConfigurations[0].LambdaFunctionArn))
+}
+
+func TestS3BucketEventBridge(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":              region,
+			"bucket_name":         UniqueBucketName("test-eventbridge"),
+			"eventbridge_enabled": true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify events are delivered to EventBridge without any other destination
+	notificationConfig := GetS3BucketNotification(t, region, bucketName)
+	assert.NotNil(t, notificationConfig.EventBridgeConfiguration)
+	assert.Empty(t, notificationConfig.LambdaFunctionConfigurations)
+	assert.Empty(t, notificationConfig.QueueConfigurations)
+	assert.Empty(t, notificationConfig.TopicConfigurations)
+}
+
+func TestS3BucketIntelligentTiering(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "intelligent-tiering"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-tiering"),
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the bucket-wide configuration archives in both tiers
+	config := GetS3BucketIntelligentTiering(t, region, bucketName, "EntireBucket")
+	assert.Equal(t, "Enabled", awssdk.StringValue(config.Status))
+	assert.Len(t, config.Tierings, 2)
+
+	tierDays := map[string]int64{}
+	for _, tiering := range config.Tierings {
+		tierDays[awssdk.StringValue(tiering.AccessTier)] = awssdk.Int64Value(tiering.Days)
+	}
+	assert.Equal(t, int64(90), tierDays["ARCHIVE_ACCESS"])
+	assert.Equal(t, int64(180), tierDays["DEEP_ARCHIVE_ACCESS"])
+
+	// Verify the filtered configuration exists
+	reports := GetS3BucketIntelligentTiering(t, region, bucketName, "Reports")
+	assert.NotNil(t, reports.Filter)
+}
+
+func TestS3BucketInventory(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "inventory"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-inventory"),
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+	inventoryBucketName := terraform.Output(t, terraformOptions, "inventory_bucket_name")
+	inventoryBucketArn := terraform.Output(t, terraformOptions, "inventory_bucket_arn")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Inventory reports may be delivered before teardown
+	defer aws.EmptyS3Bucket(t, region, inventoryBucketName)
+
+	// Verify the inventory configuration is created
+	inventory := GetS3BucketInventory(t, region, bucketName, "daily-audit")
+	assert.True(t, awssdk.BoolValue(inventory.IsEnabled))
+	assert.Equal(t, "All", awssdk.StringValue(inventory.IncludedObjectVersions))
+	assert.Equal(t, "Daily", awssdk.StringValue(inventory.Schedule.Frequency))
+	assert.Equal(t, inventoryBucketArn, awssdk.StringValue(inventory.Destination.S3BucketDestination.Bucket))
+	assert.Equal(t, "CSV", awssdk.StringValue(inventory.Destination.S3BucketDestination.Format))
+	assert.Nil(t, inventory.Destination.S3BucketDestination.Encryption)
+}
+
+func TestS3BucketInventoryKMSEncryption(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Create a key outside the module for the inventory reports
+	kmsKeyArn := CreateKMSKey(t, region, "terratest inventory report key for S3")
+	defer ScheduleKMSKeyDeletion(t, region, kmsKeyArn)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "inventory"),
+		Vars: map[string]interface{}{
+			"region":                region,
+			"bucket_name":           UniqueBucketName("test-inventory-kms"),
+			"inventory_kms_key_arn": kmsKeyArn,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+	inventoryBucketName := terraform.Output(t, terraformOptions, "inventory_bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Inventory reports may be delivered before teardown
+	defer aws.EmptyS3Bucket(t, region, inventoryBucketName)
+
+	// Verify the reports are declared as SSE-KMS encrypted with the given key
+	inventory := GetS3BucketInventory(t, region, bucketName, "daily-audit")
+	encryption := inventory.Destination.S3BucketDestination.Encryption
+	require.NotNil(t, encryption)
+	require.NotNil(t, encryption.SSEKMS)
+	assert.Equal(t, kmsKeyArn, awssdk.StringValue(encryption.SSEKMS.KeyId))
+	assert.Nil(t, encryption.SSES3)
+}
+
+func TestS3BucketDestinationBucketPolicy(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "inventory"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-destination-policy"),
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+	inventoryBucketName := terraform.Output(t, terraformOptions, "inventory_bucket_name")
+	inventoryBucketArn := terraform.Output(t, terraformOptions, "inventory_bucket_arn")
+	policies := terraform.OutputMap(t, terraformOptions, "required_destination_bucket_policies_json")
+
+	// Inventory reports may be delivered before teardown
+	defer aws.EmptyS3Bucket(t, region, inventoryBucketName)
+
+	// Verify one policy is generated for the inventory bucket
+	require.Len(t, policies, 1)
+	require.Contains(t, policies, inventoryBucketArn)
+
+	var policy struct {
+		Statement []struct {
+			Sid       string
+			Effect    string
+			Principal map[string]string
+			Action    string
+			Resource  []string
+			Condition map[string]map[string]string
+		}
+	}
+	require.NoError(t, json.Unmarshal([]byte(policies[inventoryBucketArn]), &policy))
+
+	// Verify the statement lets S3 write reports only on behalf of the source bucket
+	require.Len(t, policy.Statement, 1)
+	statement := policy.Statement[0]
+	assert.Equal(t, "AllowS3InventoryDelivery", statement.Sid)
+	assert.Equal(t, "Allow", statement.Effect)
+	assert.Equal(t, "s3.amazonaws.com", statement.Principal["Service"])
+	assert.Equal(t, "s3:PutObject", statement.Action)
+	assert.Equal(t, []string{inventoryBucketArn + "/inventory*"}, statement.Resource)
+	assert.Equal(t, "arn:aws:s3:::"+bucketName, statement.Condition["ArnLike"]["aws:SourceArn"])
+	assert.Equal(t, aws.GetAccountId(t), statement.Condition["StringEquals"]["aws:SourceAccount"])
+	assert.Equal(t, "bucket-owner-full-control", statement.Condition["StringEquals"]["s3:x-amz-acl"])
+}
+
+func TestS3BucketMetrics(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "metrics"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-metrics"),
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+	metricsIds := terraform.OutputList(t, terraformOptions, "metrics_configuration_ids")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the metrics output
+	assert.Equal(t, []string{"uploads"}, metricsIds)
+
+	// Verify the prefix-filtered metrics configuration exists
+	metrics := GetS3BucketMetrics(t, region, bucketName, "uploads")
+	assert.Equal(t, "uploads", awssdk.StringValue(metrics.Id))
+	assert.Equal(t, "uploads/", awssdk.StringValue(metrics.Filter.Prefix))
+}
+
+func TestS3BucketRequestAlarms(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "request-alarms"),
+		Vars: map[string]interface{}{
+			"region":              region,
+			"bucket_name":         UniqueBucketName("test-request-alarms"),
+			"alarm_4xx_threshold": 50,
+			"alarm_5xx_threshold": 5,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+	alarmArns := terraform.OutputMap(t, terraformOptions, "request_alarm_arns")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify one alarm per error class watches the bucket's request metrics with its threshold
+	assert.Len(t, alarmArns, 2)
+	for errorClass, threshold := range map[string]float64{"4xx": 50, "5xx": 5} {
+		alarm := GetCloudWatchMetricAlarm(t, region, fmt.Sprintf("%s-EntireBucket-%s-errors", bucketName, errorClass))
+		assert.Equal(t, alarmArns["EntireBucket-"+errorClass], awssdk.StringValue(alarm.AlarmArn))
+		assert.Equal(t, "AWS/S3", awssdk.StringValue(alarm.Namespace))
+		assert.Equal(t, errorClass+"Errors", awssdk.StringValue(alarm.MetricName))
+		assert.Equal(t, threshold, awssdk.Float64Value(alarm.Threshold))
+		assert.Equal(t, "GreaterThanThreshold", awssdk.StringValue(alarm.ComparisonOperator))
+
+		dimensions := map[string]string{}
+		for _, dimension := range alarm.Dimensions {
+			dimensions[awssdk.StringValue(dimension.Name)] = awssdk.StringValue(dimension.Value)
+		}
+		assert.Equal(t, map[string]string{"BucketName": bucketName, "FilterId": "EntireBucket"}, dimensions)
+	}
+}
+
+func TestS3BucketTransferAcceleration(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "transfer-acceleration"),
+		Vars: map[string]interface{}{
+			"region":              region,
+			"bucket_name":         UniqueBucketName("test-accelerate"),
+			"acceleration_status": "Enabled",
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+	accelerationEndpoint := terraform.Output(t, terraformOptions, "acceleration_endpoint")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify transfer acceleration is enabled
+	assert.Equal(t, "Enabled", GetS3BucketAccelerateStatus(t, region, bucketName))
+
+	// Verify the acceleration endpoint
+	assert.Equal(t, bucketName+".s3-accelerate.amazonaws.com", accelerationEndpoint)
+	assert.Equal(t, "Enabled", terraform.Output(t, terraformOptions, "acceleration_status"))
+}
+
+func TestS3BucketRequesterPays(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "requester-pays"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-payer"),
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the payment configuration
+	assert.Equal(t, "Requester", GetS3BucketRequestPayer(t, region, bucketName))
+	assert.Equal(t, "Requester", terraform.Output(t, terraformOptions, "request_payer"))
+}
+
+func TestS3BucketAbortIncompleteMultipartUpload(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-multipart"),
+			"lifecycle_rules": []map[string]interface{}{
+				{
+					"id":                                     "abort-incomplete-uploads",
+					"status":                                 "Enabled",
+					"abort_incomplete_multipart_upload_days": 7,
+				},
+			},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the rule without a filter aborts uploads after seven days
+	lifecycleRules := GetS3BucketLifecycle(t, region, bucketName)
+	if assert.Len(t, lifecycleRules, 1) {
+		assert.Equal(t, "abort-incomplete-uploads", awssdk.StringValue(lifecycleRules[0].ID))
+		if assert.NotNil(t, lifecycleRules[0].AbortIncompleteMultipartUpload) {
+			assert.Equal(t, int64(7), awssdk.Int64Value(lifecycleRules[0].AbortIncompleteMultipartUpload.DaysAfterInitiation))
+		}
+	}
+}
+
+func TestS3BucketNoncurrentVersionExpiration(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-noncurrent"),
+			"lifecycle_rules": []map[string]interface{}{
+				{
+					"id":     "expire-old-versions",
+					"status": "Enabled",
+					"noncurrent_version_expiration": map[string]interface{}{
+						"noncurrent_days":           30,
+						"newer_noncurrent_versions": 3,
+					},
+				},
+			},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the latest three noncurrent versions are kept and older ones expire after 30 days
+	lifecycleRules := GetS3BucketLifecycle(t, region, bucketName)
+	if assert.Len(t, lifecycleRules, 1) {
+		assert.Nil(t, lifecycleRules[0].Expiration)
+		if assert.NotNil(t, lifecycleRules[0].NoncurrentVersionExpiration) {
+			assert.Equal(t, int64(30), awssdk.Int64Value(lifecycleRules[0].NoncurrentVersionExpiration.NoncurrentDays))
+			assert.Equal(t, int64(3), awssdk.Int64Value(lifecycleRules[0].NoncurrentVersionExpiration.NewerNoncurrentVersions))
+		}
+	}
+}
+
+func TestS3BucketExpiredObjectDeleteMarker(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-delete-markers"),
+			"lifecycle_rules": []map[string]interface{}{
+				{
+					"id":     "clean-up-delete-markers",
+					"status": "Enabled",
+					"filter": map[string]interface{}{
+						"prefix": "uploads/",
+					},
+					"expiration": map[string]interface{}{
+						"expired_object_delete_marker": true,
+					},
+					"noncurrent_version_expiration": map[string]interface{}{
+						"noncurrent_days": 30,
+					},
+				},
+			},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify delete markers left behind by expired noncurrent versions are removed under the prefix
+	lifecycleRules := GetS3BucketLifecycle(t, region, bucketName)
+	if assert.Len(t, lifecycleRules, 1) {
+		assert.Equal(t, "uploads/", awssdk.StringValue(lifecycleRules[0].Filter.Prefix))
+		if assert.NotNil(t, lifecycleRules[0].Expiration) {
+			assert.True(t, awssdk.BoolValue(lifecycleRules[0].Expiration.ExpiredObjectDeleteMarker))
+			assert.Nil(t, lifecycleRules[0].Expiration.Days)
+			assert.Nil(t, lifecycleRules[0].Expiration.Date)
+		}
+	}
+}
+
+func TestS3BucketExpirationDeleteMarkerConflict(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-delete-markers"),
+			"lifecycle_rules": []map[string]interface{}{
+				{
+					"id":     "conflicting-expiration",
+					"status": "Enabled",
+					"expiration": map[string]interface{}{
+						"days":                         30,
+						"expired_object_delete_marker": true,
+					},
+				},
+			},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	}
+
+	// AWS does not allow delete marker cleanup alongside days or date
+	_, err := terraform.InitAndPlanE(t, terraformOptions)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "exactly one of")
+	}
+}
+
+func TestS3BucketExpirationDeleteMarkerFalse(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-delete-markers-off"),
+			"lifecycle_rules": []map[string]interface{}{
+				{
+					"id":     "expire-tagged-objects",
+					"status": "Enabled",
+					"filter": map[string]interface{}{
+						"tags": []map[string]interface{}{
+							{"key": "class", "value": "temporary"},
+						},
+					},
+					"expiration": map[string]interface{}{
+						"days":                         30,
+						"expired_object_delete_marker": false,
+					},
+				},
+			},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	}
+
+	// An explicit false flag is not delete marker cleanup, so it plans alongside days and a tag filter
+	_, err := terraform.InitAndPlanE(t, terraformOptions)
+	assert.NoError(t, err)
+}
+
+func TestS3BucketLifecycleDuplicateRuleIds(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-duplicate-rules"),
+			"lifecycle_rules": []map[string]interface{}{
+				{
+					"id":     "cleanup",
+					"status": "Enabled",
+					"filter": map[string]interface{}{
+						"prefix": "tmp/",
+					},
+					"expiration": map[string]interface{}{
+						"days": 7,
+					},
+				},
+				{
+					"id":     "cleanup",
+					"status": "Enabled",
+					"filter": map[string]interface{}{
+						"prefix": "logs/",
+					},
+					"expiration": map[string]interface{}{
+						"days": 30,
+					},
+				},
+			},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	}
+
+	// Rules sharing an id would otherwise only be rejected by AWS during apply
+	_, err := terraform.InitAndPlanE(t, terraformOptions)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "must be unique")
+		assert.Contains(t, err.Error(), "cleanup")
+	}
+}
+
+func TestS3BucketManagedKMSKey(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":               region,
+			"bucket_name":          UniqueBucketName("test-kms"),
+			"encryption_algorithm": "aws:kms",
+			"create_kms_key":       true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+	kmsKeyArn := terraform.Output(t, terraformOptions, "kms_key_arn")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the created key is used for default encryption
+	assert.Contains(t, kmsKeyArn, "arn:aws:kms:"+region+":")
+	assert.Contains(t, terraform.OutputList(t, terraformOptions, "all_resource_arns"), kmsKeyArn)
+	encryption := GetS3BucketEncryption(t, region, bucketName)
+	if assert.NotEmpty(t, encryption.Rules) {
+		defaultEncryption := encryption.Rules[0].ApplyServerSideEncryptionByDefault
+		assert.Equal(t, "aws:kms", awssdk.StringValue(defaultEncryption.SSEAlgorithm))
+		assert.Equal(t, kmsKeyArn, awssdk.StringValue(defaultEncryption.KMSMasterKeyID))
+	}
+}
+
+func TestS3BucketManagedKMSKeySettings(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":                          region,
+			"bucket_name":                     UniqueBucketName("test-kms-settings"),
+			"encryption_algorithm":            "aws:kms",
+			"create_kms_key":                  true,
+			"kms_key_deletion_window_in_days": 7,
+			"kms_key_tags": map[string]string{
+				"CostCenter": "Security",
+			},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+	kmsKeyArn := terraform.Output(t, terraformOptions, "kms_key_arn")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify rotation is on and the key tags override the bucket tags
+	assert.True(t, GetKMSKeyRotationEnabled(t, region, kmsKeyArn))
+	keyTags := GetKMSKeyTags(t, region, kmsKeyArn)
+	assert.Equal(t, "Security", keyTags["CostCenter"])
+	assert.Equal(t, "Terraform", keyTags["ManagedBy"])
+	AssertS3BucketTags(t, region, bucketName, map[string]string{"CostCenter": "IT"})
+
+	// The deletion window is only visible once the key is scheduled for deletion
+	terraform.Destroy(t, terraformOptions)
+	keyMetadata := GetKMSKeyMetadata(t, region, kmsKeyArn)
+	assert.Equal(t, kms.KeyStatePendingDeletion, awssdk.StringValue(keyMetadata.KeyState))
+	if assert.NotNil(t, keyMetadata.DeletionDate) {
+		assert.WithinDuration(t, time.Now().Add(7*24*time.Hour), awssdk.TimeValue(keyMetadata.DeletionDate), 24*time.Hour)
+	}
+}
+
+func TestS3BucketCustomPolicy(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	bucketName := UniqueBucketName("test-policy")
+	policy := fmt.Sprintf(`{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "DenyInsecureTransport",
+      "Effect": "Deny",
+      "Principal": "*",
+      "Action": "s3:*",
+      "Resource": ["arn:aws:s3:::%[1]s", "arn:aws:s3:::%[1]s/*"],
+      "Condition": {"Bool": {"aws:SecureTransport": "false"}}
+    }
+  ]
+}`, bucketName)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":        region,
+			"bucket_name":   bucketName,
+			"bucket_policy": policy,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the policy is attached to the bucket
+	appliedPolicy := aws.GetS3BucketPolicy(t, region, bucketName)
+	assert.Contains(t, appliedPolicy, "DenyInsecureTransport")
+	assert.Contains(t, appliedPolicy, "aws:SecureTransport")
+
+	// Verify the output reflects the applied policy
+	assert.Contains(t, terraform.Output(t, terraformOptions, "bucket_policy_json"), "DenyInsecureTransport")
+}
+
+func TestS3BucketEnforceSSL(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-ssl"),
+			"enforce_ssl": true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the policy denies requests over plain HTTP
+	policy := aws.GetS3BucketPolicy(t, region, bucketName)
+	assert.Contains(t, policy, "DenyInsecureTransport")
+	assert.Contains(t, policy, `"Effect":"Deny"`)
+	assert.Contains(t, policy, `"aws:SecureTransport":"false"`)
+}
+
+func TestS3BucketMinimumTLSVersion(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":                  region,
+			"bucket_name":             UniqueBucketName("test-tls"),
+			"enforce_ssl":             true,
+			"enforce_min_tls_version": "1.2",
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify both deny statements are present alongside each other
+	policy := aws.GetS3BucketPolicy(t, region, bucketName)
+	assert.Contains(t, policy, "DenyInsecureTransport")
+	assert.Contains(t, policy, "DenyOutdatedTLS")
+	assert.Contains(t, policy, `"NumericLessThan":{"s3:TlsVersion":"1.2"}`)
+}
+
+func TestS3BucketWebsitePublicAccessBlock(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "website"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-website-pab"),
+			// ACLs stay blocked; only the public read policy is allowed through
+			"block_public_acls":       true,
+			"ignore_public_acls":      true,
+			"block_public_policy":     false,
+			"restrict_public_buckets": false,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the public access block flags
+	publicAccessBlock := GetS3BucketPublicAccessBlock(t, region, bucketName)
+	assert.True(t, awssdk.BoolValue(publicAccessBlock.BlockPublicAcls))
+	assert.True(t, awssdk.BoolValue(publicAccessBlock.IgnorePublicAcls))
+	assert.False(t, awssdk.BoolValue(publicAccessBlock.BlockPublicPolicy))
+	assert.False(t, awssdk.BoolValue(publicAccessBlock.RestrictPublicBuckets))
+
+	// Verify the public read policy was accepted
+	assert.Contains(t, aws.GetS3BucketPolicy(t, region, bucketName), "PublicReadGetObject")
+}
+
+func TestS3BucketWebsiteRedirect(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "website-redirect"),
+		Vars: map[string]interface{}{
+			"region":             region,
+			"bucket_name":        UniqueBucketName("test-apex"),
+			"redirect_host_name": "www.example.com",
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify every request is redirected and no documents are served
+	websiteConfig := GetS3BucketWebsite(t, region, bucketName)
+	assert.Nil(t, websiteConfig.IndexDocument)
+	assert.Nil(t, websiteConfig.ErrorDocument)
+	if assert.NotNil(t, websiteConfig.RedirectAllRequestsTo) {
+		assert.Equal(t, "www.example.com", awssdk.StringValue(websiteConfig.RedirectAllRequestsTo.HostName))
+		assert.Equal(t, "https", awssdk.StringValue(websiteConfig.RedirectAllRequestsTo.Protocol))
+	}
+}
+
+func TestS3BucketTagPrecedence(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-tags"),
+			// Owner is also set to DevOps in the example's default_tags
+			"tags": map[string]string{
+				"Owner": "DataPlatform",
+			},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+	effectiveTags := terraform.OutputMap(t, terraformOptions, "effective_tags")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the per-bucket value wins over the default tag
+	assert.Equal(t, "DataPlatform", effectiveTags["Owner"])
+	assert.Equal(t, "BasicExample", effectiveTags["Project"])
+
+	AssertS3BucketTags(t, region, bucketName, map[string]string{"Owner": "DataPlatform"})
+}
+
+func TestS3BucketVersioningSuspended(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":            region,
+			"bucket_name":       UniqueBucketName("test-suspended"),
+			"versioning_status": "Suspended",
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify versioning is suspended rather than removed
+	assert.Equal(t, "Suspended", aws.GetS3BucketVersioning(t, region, bucketName))
+	assert.Equal(t, "Suspended", terraform.Output(t, terraformOptions, "bucket_versioning_status"))
+}
+
+func TestS3BucketExternalKMSKey(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Create a key outside the module, as an existing key would be
+	kmsKeyArn := CreateKMSKey(t, region, "terratest external key for S3")
+	defer ScheduleKMSKeyDeletion(t, region, kmsKeyArn)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":               region,
+			"bucket_name":          UniqueBucketName("test-external-kms"),
+			"encryption_algorithm": "aws:kms",
+			"kms_key_arn":          kmsKeyArn,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the provided key is reported as the effective key
+	assert.Equal(t, kmsKeyArn, terraform.Output(t, terraformOptions, "kms_key_arn"))
+
+	// Verify objects are written and read back with the provided key
+	PutS3ObjectContents(t, region, bucketName, "encrypted.txt", "hello")
+	assert.Equal(t, "hello", aws.GetS3ObjectContents(t, region, bucketName, "encrypted.txt"))
+	assert.Equal(t, kmsKeyArn, GetS3ObjectKMSKeyId(t, region, bucketName, "encrypted.txt"))
+}
+
+func TestS3BucketCloudFrontOAI(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "cloudfront-oai"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-oai"),
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+	oaiID := terraform.Output(t, terraformOptions, "cloudfront_oai_id")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the OAI is granted GetObject alongside the TLS deny statement
+	policy := aws.GetS3BucketPolicy(t, region, bucketName)
+	assert.Contains(t, policy, "AllowCloudFrontOAIRead")
+	assert.Contains(t, policy, "CloudFront Origin Access Identity "+oaiID)
+	assert.Contains(t, policy, `"Action":"s3:GetObject"`)
+	assert.Contains(t, policy, "DenyInsecureTransport")
+
+	// Verify the bucket is still private
+	publicAccessBlock := GetS3BucketPublicAccessBlock(t, region, bucketName)
+	assert.True(t, awssdk.BoolValue(publicAccessBlock.BlockPublicPolicy))
+	assert.True(t, awssdk.BoolValue(publicAccessBlock.RestrictPublicBuckets))
+}
+
+func TestS3BucketCloudFrontOAC(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// The policy only references the distribution, so it does not need to exist
+	distributionArn := "arn:aws:cloudfront::123456789012:distribution/E1234567890ABC"
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":                       region,
+			"bucket_name":                  UniqueBucketName("test-oac"),
+			"enforce_ssl":                  true,
+			"cloudfront_distribution_arns": []string{distributionArn},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the CloudFront service principal is scoped to the distribution
+	policy := aws.GetS3BucketPolicy(t, region, bucketName)
+	assert.Contains(t, policy, "AllowCloudFrontOACRead")
+	assert.Contains(t, policy, `"Service":"cloudfront.amazonaws.com"`)
+	assert.Contains(t, policy, `"AWS:SourceArn":"`+distributionArn+`"`)
+	assert.Contains(t, policy, "DenyInsecureTransport")
+}
+
+func TestS3BucketVPCEndpointRestriction(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// The policy only references the endpoint, so it does not need to exist
+	vpcEndpointID := "vpce-0123456789abcdef0"
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":                    region,
+			"bucket_name":               UniqueBucketName("test-vpce"),
+			"enforce_ssl":               true,
+			"restrict_to_vpc_endpoints": []string{vpcEndpointID},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify access outside the endpoint is denied
+	policy := aws.GetS3BucketPolicy(t, region, bucketName)
+	assert.Contains(t, policy, "DenyAccessOutsideVPCEndpoints")
+	assert.Contains(t, policy, `"StringNotEquals":{"aws:SourceVpce":"`+vpcEndpointID+`"}`)
+	assert.Contains(t, policy, "DenyInsecureTransport")
+}
+
+func TestS3BucketDisabled(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	bucketName := UniqueBucketName("test-disabled")
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": bucketName,
+			"create":      false,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Verify no bucket was created
+	assert.Error(t, aws.AssertS3BucketExistsE(t, region, bucketName))
+
+	// Verify outputs resolve without error and carry no bucket values
+	outputs := terraform.OutputAll(t, terraformOptions)
+	assert.Nil(t, outputs["bucket_name"])
+	assert.Nil(t, outputs["bucket_arn"])
+	assert.Nil(t, outputs["bucket"])
+	assert.Equal(t, false, outputs["object_lock_enabled"])
+	assert.Equal(t, "Disabled", outputs["versioning_status"])
+	assert.Equal(t, "Disabled", outputs["bucket_versioning_status"])
+	assert.Empty(t, outputs["all_resource_arns"])
+}
+
+func TestS3BucketEncryptedReplication(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+	replicaRegion := GetTestReplicaRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "replication-encrypted"),
+		Vars: map[string]interface{}{
+			"region":         region,
+			"replica_region": replicaRegion,
+			"bucket_name":    UniqueBucketName("test-replication-kms"),
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	sourceBucketName := terraform.Output(t, terraformOptions, "source_bucket_name")
+	replicaKmsKeyArn := terraform.Output(t, terraformOptions, "replica_kms_key_arn")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, sourceBucketName)
+
+	// Verify KMS objects are selected without explicit source_selection_criteria, replicas use the destination key,
+	// and RTC is enabled with metrics
+	replication := GetS3BucketReplication(t, region, sourceBucketName)
+	if assert.Len(t, replication.Rules, 1) {
+		rule := replication.Rules[0]
+		assert.Equal(t, "Enabled", awssdk.StringValue(rule.SourceSelectionCriteria.SseKmsEncryptedObjects.Status))
+		assert.Equal(t, replicaKmsKeyArn, awssdk.StringValue(rule.Destination.EncryptionConfiguration.ReplicaKmsKeyID))
+		assert.Equal(t, "Enabled", awssdk.StringValue(rule.Destination.ReplicationTime.Status))
+		assert.Equal(t, int64(15), awssdk.Int64Value(rule.Destination.ReplicationTime.Time.Minutes))
+		assert.Equal(t, "Enabled", awssdk.StringValue(rule.Destination.Metrics.Status))
+	}
+}
+
+func TestS3ReplicationKMSKeyPolicy(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+	replicaRegion := GetTestReplicaRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "replication-encrypted"),
+		Vars: map[string]interface{}{
+			"region":         region,
+			"replica_region": replicaRegion,
+			"bucket_name":    UniqueBucketName("test-replication-key-policy"),
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	sourceBucketName := terraform.Output(t, terraformOptions, "source_bucket_name")
+	replicaBucketName := terraform.Output(t, terraformOptions, "replica_bucket_name")
+	replicaKmsKeyArn := terraform.Output(t, terraformOptions, "replica_kms_key_arn")
+	replicationRoleArn := terraform.Output(t, terraformOptions, "replication_role_arn")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, sourceBucketName)
+
+	// Verify the replica key policy grants key use to the replication role
+	var keyPolicy struct {
+		Statement []struct {
+			Sid       string
+			Principal struct {
+				AWS interface{}
+			}
+			Action []string
+		}
+	}
+	require.NoError(t, json.Unmarshal([]byte(GetKMSKeyPolicy(t, replicaRegion, replicaKmsKeyArn)), &keyPolicy))
+
+	sids := []string{}
+	for _, statement := range keyPolicy.Statement {
+		sids = append(sids, statement.Sid)
+		if statement.Sid == "AllowKeyUse" {
+			assert.Equal(t, replicationRoleArn, statement.Principal.AWS)
+			assert.Contains(t, statement.Action, "kms:Encrypt")
+			assert.Contains(t, statement.Action, "kms:Decrypt")
+		}
+	}
+	assert.ElementsMatch(t, []string{"EnableIAMUserPermissions", "AllowKeyUse"}, sids)
+
+	// Verify an object replicates into the KMS-encrypted replica bucket and reads back through the replica key
+	PutS3ObjectContents(t, region, sourceBucketName, "encrypted.txt", "replicated through kms")
+
+	replicated := retry.DoWithRetry(t, "Wait for encrypted object replication", 30, 10*time.Second, func() (string, error) {
+		return aws.GetS3ObjectContentsE(t, replicaRegion, replicaBucketName, "encrypted.txt")
+	})
+	assert.Equal(t, "replicated through kms", replicated)
+	assert.Equal(t, replicaKmsKeyArn, GetS3ObjectKMSKeyId(t, replicaRegion, replicaBucketName, "encrypted.txt"))
+}
+
+func TestS3BucketReplicationLatencyAlarm(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+	replicaRegion := GetTestReplicaRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "replication-encrypted"),
+		Vars: map[string]interface{}{
+			"region":                                region,
+			"replica_region":                        replicaRegion,
+			"bucket_name":                           UniqueBucketName("test-replication-latency"),
+			"create_replication_latency_alarm":      true,
+			"replication_latency_threshold_seconds": 600,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	sourceBucketName := terraform.Output(t, terraformOptions, "source_bucket_name")
+	replicaBucketName := terraform.Output(t, terraformOptions, "replica_bucket_name")
+	alarmArns := terraform.OutputMap(t, terraformOptions, "replication_latency_alarm_arns")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, sourceBucketName)
+
+	// Verify the RTC rule gets a latency alarm with the configured threshold
+	assert.Len(t, alarmArns, 1)
+	alarm := GetCloudWatchMetricAlarm(t, region, fmt.Sprintf("%s-encrypted-disaster-recovery-replication-latency", sourceBucketName))
+	assert.Equal(t, alarmArns["encrypted-disaster-recovery"], awssdk.StringValue(alarm.AlarmArn))
+	assert.Equal(t, "AWS/S3", awssdk.StringValue(alarm.Namespace))
+	assert.Equal(t, "ReplicationLatency", awssdk.StringValue(alarm.MetricName))
+	assert.Equal(t, float64(600), awssdk.Float64Value(alarm.Threshold))
+	assert.Equal(t, "GreaterThanThreshold", awssdk.StringValue(alarm.ComparisonOperator))
+
+	dimensions := map[string]string{}
+	for _, dimension := range alarm.Dimensions {
+		dimensions[awssdk.StringValue(dimension.Name)] = awssdk.StringValue(dimension.Value)
+	}
+	assert.Equal(t, map[string]string{
+		"SourceBucket":      sourceBucketName,
+		"DestinationBucket": replicaBucketName,
+		"RuleId":            "encrypted-disaster-recovery",
+	}, dimensions)
+}
+
+func TestS3BucketReplicationLatencyAlarmRequiresMetrics(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+	bucketName := UniqueBucketName("test-latency-no-metrics")
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":                           region,
+			"bucket_name":                      bucketName,
+			"create_replication_latency_alarm": true,
+			"replication_configuration": map[string]interface{}{
+				"rules": []map[string]interface{}{
+					{
+						"id":     "no-metrics",
+						"status": "Enabled",
+						"destination": map[string]interface{}{
+							"bucket": fmt.Sprintf("arn:aws:s3:::%s-replica", bucketName),
+						},
+					},
+				},
+			},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	}
+
+	// Without replication metrics S3 publishes no latency, so the alarm would never fire
+	_, err := terraform.InitAndPlanE(t, terraformOptions)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "replication metrics")
+	}
+}
+
+func TestS3BucketEncryptedReplicationRequiresReplicaKey(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+	replicaRegion := GetTestReplicaRegion(t)
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "replication-encrypted"),
+		Vars: map[string]interface{}{
+			"region":           region,
+			"replica_region":   replicaRegion,
+			"bucket_name":      UniqueBucketName("test-replication-no-key"),
+			"encrypt_replicas": false,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	}
+
+	// An SSE-KMS source replicated without a replica key must fail at plan time
+	_, err := terraform.InitAndPlanE(t, terraformOptions)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "replica_kms_key_id")
+	}
+}
+
+func TestS3BucketReplicationToSelf(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+	bucketName := UniqueBucketName("test-replication-self")
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": bucketName,
+			"replication_configuration": map[string]interface{}{
+				"rules": []map[string]interface{}{
+					{
+						"id":     "loop",
+						"status": "Enabled",
+						"destination": map[string]interface{}{
+							"bucket": fmt.Sprintf("arn:aws:s3:::%s", bucketName),
+						},
+					},
+				},
+			},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	}
+
+	// Replicating into the source bucket must fail at plan time rather than with a provider error on apply
+	_, err := terraform.InitAndPlanE(t, terraformOptions)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "cannot replicate to itself")
+		assert.Contains(t, err.Error(), "loop")
+	}
+}
+
+func TestS3BucketReplicationTagFilter(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+	replicaRegion := GetTestReplicaRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "replication"),
+		Vars: map[string]interface{}{
+			"region":         region,
+			"replica_region": replicaRegion,
+			"bucket_name":    UniqueBucketName("test-replication-tags"),
+			"replicate_tags": map[string]string{
+				"replicate": "true",
+			},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	sourceBucketName := terraform.Output(t, terraformOptions, "source_bucket_name")
+	replicaBucketName := terraform.Output(t, terraformOptions, "replica_bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, sourceBucketName)
+
+	// Verify the rule uses a V2 tag filter with delete marker replication
+	replication := GetS3BucketReplication(t, region, sourceBucketName)
+	if assert.Len(t, replication.Rules, 1) {
+		rule := replication.Rules[0]
+		if assert.NotNil(t, rule.Filter.Tag) {
+			assert.Equal(t, "replicate", awssdk.StringValue(rule.Filter.Tag.Key))
+			assert.Equal(t, "true", awssdk.StringValue(rule.Filter.Tag.Value))
+		}
+		assert.Equal(t, "Enabled", awssdk.StringValue(rule.DeleteMarkerReplication.Status))
+	}
+
+	// Verify only the tagged object is replicated
+	PutS3ObjectContentsWithTagging(t, region, sourceBucketName, "tagged.txt", "tagged", "replicate=true")
+	PutS3ObjectContents(t, region, sourceBucketName, "untagged.txt", "untagged")
+
+	replicated := retry.DoWithRetry(t, "Wait for tagged object replication", 30, 10*time.Second, func() (string, error) {
+		return aws.GetS3ObjectContentsE(t, replicaRegion, replicaBucketName, "tagged.txt")
+	})
+	assert.Equal(t, "tagged", replicated)
+	assert.Empty(t, GetS3ObjectReplicationStatus(t, region, sourceBucketName, "untagged.txt"))
+	_, err := aws.GetS3ObjectContentsE(t, replicaRegion, replicaBucketName, "untagged.txt")
+	assert.Error(t, err)
+}
+
+func TestS3BucketNameValidation(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	testCases := []struct {
+		name          string
+		bucketName    string
+		expectedError string
+	}{
+		{"TooShort", "ab", "3 and 63 characters"},
+		{"TooLong", "a" + strings.Repeat("b", 63), "3 and 63 characters"},
+		{"Uppercase", "Invalid-Bucket", "lowercase letters"},
+		{"Underscore", "invalid_bucket", "lowercase letters"},
+		{"LeadingHyphen", "-invalid-bucket", "start and end"},
+		{"TrailingDot", "invalid-bucket.", "start and end"},
+		{"ConsecutiveDots", "invalid..bucket", "adjacent dots"},
+		{"IPAddress", "192.168.1.1", "IP address"},
+		{"ReservedPrefix", "xn--invalid-bucket", "'xn--'"},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: CopyExampleToTemp(t, "basic"),
+				Vars: map[string]interface{}{
+					"region":      region,
+					"bucket_name": testCase.bucketName,
+				},
+				EnvVars: map[string]string{
+					"AWS_DEFAULT_REGION": region,
+				},
+			}
+
+			// Invalid names must be rejected at plan time
+			_, err := terraform.InitAndPlanE(t, terraformOptions)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestS3BucketPrefix(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	prefix := UniqueBucketName("test-prefix") + "-"
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":        region,
+			"bucket_prefix": prefix,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Verify AWS generated a unique name from the prefix
+	assert.True(t, strings.HasPrefix(bucketName, prefix), "bucket name %q should start with %q", bucketName, prefix)
+	assert.Greater(t, len(bucketName), len(prefix))
+	eventuallyBucketReady(t, region, bucketName)
+}
+
+func TestS3BucketExpectedBucketOwner(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+	accountId := aws.GetAccountId(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":                region,
+			"bucket_name":           UniqueBucketName("test-bucket-owner"),
+			"expected_bucket_owner": accountId,
+			"lifecycle_rules": []map[string]interface{}{
+				{
+					"id":                                     "abort-incomplete-uploads",
+					"status":                                 "Enabled",
+					"abort_incomplete_multipart_upload_days": 7,
+				},
+			},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the configuration resources were applied with the owner check
+	var state struct {
+		Values struct {
+			RootModule struct {
+				ChildModules []struct {
+					Resources []struct {
+						Address string
+						Values  map[string]interface{}
+					}
+				} `json:"child_modules"`
+			} `json:"root_module"`
+		}
+	}
+	require.NoError(t, json.Unmarshal([]byte(terraform.Show(t, terraformOptions)), &state))
+
+	owners := map[string]interface{}{}
+	for _, module := range state.Values.RootModule.ChildModules {
+		for _, resource := range module.Resources {
+			if owner, ok := resource.Values["expected_bucket_owner"]; ok {
+				owners[resource.Address] = owner
+			}
+		}
+	}
+	assert.Equal(t, map[string]interface{}{
+		"module.s3_bucket.aws_s3_bucket_versioning.this[0]":                           accountId,
+		"module.s3_bucket.aws_s3_bucket_server_side_encryption_configuration.this[0]": accountId,
+		"module.s3_bucket.aws_s3_bucket_lifecycle_configuration.this[0]":              accountId,
+	}, owners)
+}
+
+func TestS3BucketExpectedRegionMismatch(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	expectedRegion := "eu-north-1"
+	if region == expectedRegion {
+		expectedRegion = "us-west-2"
+	}
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":          region,
+			"bucket_name":     UniqueBucketName("test-expected-region"),
+			"expected_region": expectedRegion,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	}
+
+	// A provider pointed at the wrong region must be caught before the bucket is created
+	_, err := terraform.InitAndPlanE(t, terraformOptions)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "expected_region")
+	}
+}
+
+func TestS3MultiBucket(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "multi-bucket"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"name_prefix": UniqueBucketName("test-multi"),
+			"buckets": map[string]interface{}{
+				"raw": map[string]interface{}{
+					"purpose": "raw-data",
+					"tags":    map[string]string{"DataClass": "Internal"},
+				},
+				"processed": map[string]interface{}{
+					"purpose": "processed-data",
+				},
+				"scratch": map[string]interface{}{
+					"purpose":           "scratch-space",
+					"versioning_status": "Suspended",
+				},
+			},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketNames := terraform.OutputMap(t, terraformOptions, "bucket_names")
+	assert.Len(t, bucketNames, 3)
+
+	// Verify each bucket exists with its own settings
+	expectedVersioning := map[string]string{
+		"raw":       "Enabled",
+		"processed": "Enabled",
+		"scratch":   "Suspended",
+	}
+	for key, versioning := range expectedVersioning {
+		bucketName := bucketNames[key]
+		eventuallyBucketReady(t, region, bucketName)
+		assert.Equal(t, versioning, aws.GetS3BucketVersioning(t, region, bucketName))
+	}
+
+	AssertS3BucketTags(t, region, bucketNames["raw"], map[string]string{
+		"DataClass": "Internal",
+		"Purpose":   "raw-data",
+	})
+}
+
+func TestS3BucketTags(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+	bucketName := UniqueBucketName("test-tagged")
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": bucketName,
+			"tags": map[string]string{
+				"Team":       "storage",
+				"CostCenter": "1234",
+			},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify caller tags and the tags the module always adds
+	AssertS3BucketTags(t, region, bucketName, map[string]string{
+		"Team":       "storage",
+		"CostCenter": "1234",
+		"Name":       bucketName,
+		"ManagedBy":  "Terraform",
+		"Module":     "terraform-aws-s3",
+	})
+}
+
+func TestS3BucketEventuallyReady(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+	bucketName := UniqueBucketName("test-ready")
+
+	// Create the bucket directly and check it straight away, racing S3 eventual consistency
+	aws.CreateS3Bucket(t, region, bucketName)
+	defer aws.DeleteS3Bucket(t, region, bucketName)
+
+	// The helper retries until the bucket can be read instead of failing on the first check
+	eventuallyBucketReady(t, region, bucketName)
+	assert.NotNil(t, GetS3BucketEncryption(t, region, bucketName))
+}
+
+func TestS3BucketEncryptionAlgorithms(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	testCases := []struct {
+		name      string
+		algorithm string
+		extraVars map[string]interface{}
+	}{
+		{"SSE-S3", "AES256", nil},
+		{"SSE-KMS", "aws:kms", map[string]interface{}{"create_kms_key": true}},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			vars := map[string]interface{}{
+				"region":               region,
+				"bucket_name":          UniqueBucketName("test-encryption"),
+				"encryption_algorithm": testCase.algorithm,
+			}
+			for key, value := range testCase.extraVars {
+				vars[key] = value
+			}
+
+			// Each case applies its own copy of the example so it tears down independently
+			terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+				TerraformDir: CopyExampleToTemp(t, "basic"),
+				Vars:         vars,
+				EnvVars: map[string]string{
+					"AWS_DEFAULT_REGION": region,
+				},
+			})
+
+			// Clean up resources
+			defer terraform.Destroy(t, terraformOptions)
+
+			// Run Terraform
+			terraform.InitAndApply(t, terraformOptions)
+
+			// Get outputs
+			bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+			// Wait until the bucket is ready before asserting on it
+			eventuallyBucketReady(t, region, bucketName)
+
+			// Verify the default encryption rule uses the requested algorithm
+			encryption := GetS3BucketEncryption(t, region, bucketName)
+			if assert.Len(t, encryption.Rules, 1) {
+				sse := encryption.Rules[0].ApplyServerSideEncryptionByDefault
+				assert.Equal(t, testCase.algorithm, awssdk.StringValue(sse.SSEAlgorithm))
+				if testCase.algorithm == "aws:kms" {
+					assert.NotEmpty(t, awssdk.StringValue(sse.KMSMasterKeyID))
+				} else {
+					assert.Empty(t, awssdk.StringValue(sse.KMSMasterKeyID))
+				}
+			}
+		})
+	}
+}
+
+func TestS3BucketEncryptionEnforced(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	testCases := []struct {
+		name      string
+		algorithm string
+		extraVars map[string]interface{}
+	}{
+		{"SSE-S3", "AES256", nil},
+		{"SSE-KMS", "aws:kms", map[string]interface{}{"create_kms_key": true}},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			vars := map[string]interface{}{
+				"region":               region,
+				"bucket_name":          UniqueBucketName("test-sse-enforced"),
+				"encryption_algorithm": testCase.algorithm,
+			}
+			for key, value := range testCase.extraVars {
+				vars[key] = value
+			}
+
+			// Each case applies its own copy of the example so it tears down independently
+			terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+				TerraformDir: CopyExampleToTemp(t, "basic"),
+				Vars:         vars,
+				EnvVars: map[string]string{
+					"AWS_DEFAULT_REGION": region,
+				},
+			})
+
+			// Clean up resources
+			defer terraform.Destroy(t, terraformOptions)
+
+			// Run Terraform
+			terraform.InitAndApply(t, terraformOptions)
+
+			// Get outputs
+			bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+			// Wait until the bucket is ready before asserting on it
+			eventuallyBucketReady(t, region, bucketName)
+
+			// Objects written during the test must be removed before the bucket is destroyed
+			defer aws.EmptyS3Bucket(t, region, bucketName)
+
+			// Verify an upload without encryption headers is encrypted by the bucket default
+			output, body := PutAndGetObject(t, region, bucketName, "encrypted.txt", "hello")
+			assert.Equal(t, "hello", body)
+			assert.Equal(t, testCase.algorithm, awssdk.StringValue(output.ServerSideEncryption))
+			if testCase.algorithm == "aws:kms" {
+				// kms_key_arn is null, and so absent, without SSE-KMS
+				assert.Equal(t, terraform.Output(t, terraformOptions, "kms_key_arn"), awssdk.StringValue(output.SSEKMSKeyId))
+				assert.True(t, awssdk.BoolValue(output.BucketKeyEnabled))
+			} else {
+				assert.Empty(t, awssdk.StringValue(output.SSEKMSKeyId))
+			}
+		})
+	}
+}
+
+func TestS3BucketEncryptionObject(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Create a key outside the module and select it through the encryption object
+	kmsKeyArn := CreateKMSKey(t, region, "terratest encryption object key for S3")
+	defer ScheduleKMSKeyDeletion(t, region, kmsKeyArn)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-encryption-object"),
+			"encryption": map[string]interface{}{
+				"sse_algorithm":      "aws:kms",
+				"kms_master_key_id":  kmsKeyArn,
+				"bucket_key_enabled": false,
+			},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the encryption object drives the default encryption rule
+	encryption := GetS3BucketEncryption(t, region, bucketName)
+	if assert.Len(t, encryption.Rules, 1) {
+		rule := encryption.Rules[0]
+		assert.Equal(t, "aws:kms", awssdk.StringValue(rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm))
+		assert.Equal(t, kmsKeyArn, awssdk.StringValue(rule.ApplyServerSideEncryptionByDefault.KMSMasterKeyID))
+		assert.False(t, awssdk.BoolValue(rule.BucketKeyEnabled))
+	}
+}
+
+func TestS3BucketStorageLens(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+	bucketName := UniqueBucketName("test-lens")
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "storage-lens"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": bucketName,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketArn := terraform.Output(t, terraformOptions, "bucket_arn")
+	assert.NotEmpty(t, terraform.Output(t, terraformOptions, "storage_lens_configuration_arn"))
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the dashboard is enabled and includes only this bucket
+	config := GetS3StorageLensConfiguration(t, region, aws.GetAccountId(t), bucketName+"-lens")
+	assert.True(t, awssdk.BoolValue(config.IsEnabled))
+	if assert.NotNil(t, config.Include) {
+		assert.Equal(t, []string{bucketArn}, awssdk.StringValueSlice(config.Include.Buckets))
+	}
+}
+
+func TestS3BucketAccessPoint(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+	accessPointName := UniqueBucketName("test-ap")
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "access-point"),
+		Vars: map[string]interface{}{
+			"region":            region,
+			"bucket_name":       UniqueBucketName("test-access-point"),
+			"access_point_name": accessPointName,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+	accessPointArns := terraform.OutputMap(t, terraformOptions, "access_point_arns")
+	vpcId := terraform.Output(t, terraformOptions, "vpc_id")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the access point ARN and that it only accepts requests from the VPC
+	accountId := aws.GetAccountId(t)
+	assert.Equal(t, fmt.Sprintf("arn:aws:s3:%s:%s:accesspoint/%s", region, accountId, accessPointName), accessPointArns[accessPointName])
+
+	accessPoint := GetS3AccessPoint(t, region, accountId, accessPointName)
+	assert.Equal(t, bucketName, awssdk.StringValue(accessPoint.Bucket))
+	assert.Equal(t, "VPC", awssdk.StringValue(accessPoint.NetworkOrigin))
+	if assert.NotNil(t, accessPoint.VpcConfiguration) {
+		assert.Equal(t, vpcId, awssdk.StringValue(accessPoint.VpcConfiguration.VpcId))
+	}
+}
+
+func TestS3BucketObjectLambdaAccessPoint(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+	objectLambdaName := UniqueBucketName("test-olap")
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "object-lambda"),
+		Vars: map[string]interface{}{
+			"region":                          region,
+			"bucket_name":                     UniqueBucketName("test-object-lambda"),
+			"object_lambda_access_point_name": objectLambdaName,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+	accessPointArns := terraform.OutputMap(t, terraformOptions, "access_point_arns")
+	objectLambdaArns := terraform.OutputMap(t, terraformOptions, "object_lambda_access_point_arns")
+	functionArn := terraform.Output(t, terraformOptions, "transform_function_arn")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the module created the supporting access point and the Object Lambda access point on top of it
+	supportingAccessPointArn := accessPointArns[objectLambdaName+"-ap"]
+	assert.NotEmpty(t, supportingAccessPointArn)
+	assert.NotEmpty(t, objectLambdaArns[objectLambdaName])
+
+	config := GetS3ObjectLambdaAccessPointConfiguration(t, region, aws.GetAccountId(t), objectLambdaName)
+	assert.Equal(t, supportingAccessPointArn, awssdk.StringValue(config.SupportingAccessPoint))
+	if assert.Len(t, config.TransformationConfigurations, 1) {
+		transformation := config.TransformationConfigurations[0]
+		assert.Equal(t, []string{"GetObject"}, awssdk.StringValueSlice(transformation.Actions))
+		assert.Equal(t, functionArn, awssdk.StringValue(transformation.ContentTransformation.AwsLambda.FunctionArn))
+	}
+}
+
+func TestS3BucketLifecycleObjectSize(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-object-size"),
+			"lifecycle_rules": []map[string]interface{}{
+				{
+					"id":     "large-objects-to-glacier",
+					"status": "Enabled",
+					"filter": map[string]interface{}{
+						"object_size_greater_than": 131072,
+					},
+					"transitions": []map[string]interface{}{
+						{"days": 30, "storage_class": "GLACIER"},
+					},
+				},
+				{
+					"id":     "expire-large-logs",
+					"status": "Enabled",
+					"filter": map[string]interface{}{
+						"prefix":                   "logs/",
+						"object_size_greater_than": 131072,
+					},
+					"expiration": map[string]interface{}{
+						"days": 365,
+					},
+				},
+			},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify a size-only filter is rendered directly and a size with a prefix is rendered in an and block
+	rules := map[string]*s3.LifecycleRule{}
+	for _, rule := range GetS3BucketLifecycle(t, region, bucketName) {
+		rules[awssdk.StringValue(rule.ID)] = rule
+	}
+
+	if rule, ok := rules["large-objects-to-glacier"]; assert.True(t, ok) {
+		assert.Equal(t, int64(131072), awssdk.Int64Value(rule.Filter.ObjectSizeGreaterThan))
+		assert.Nil(t, rule.Filter.And)
+		if assert.Len(t, rule.Transitions, 1) {
+			assert.Equal(t, "GLACIER", awssdk.StringValue(rule.Transitions[0].StorageClass))
+		}
+	}
+
+	if rule, ok := rules["expire-large-logs"]; assert.True(t, ok) && assert.NotNil(t, rule.Filter.And) {
+		assert.Equal(t, "logs/", awssdk.StringValue(rule.Filter.And.Prefix))
+		assert.Equal(t, int64(131072), awssdk.Int64Value(rule.Filter.And.ObjectSizeGreaterThan))
+	}
+}
+
+func TestS3BucketTransitionDefaultMinimumObjectSize(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":                                 region,
+			"bucket_name":                            UniqueBucketName("test-transition-size"),
+			"transition_default_minimum_object_size": "varies_by_storage_class",
+			"lifecycle_rules": []map[string]interface{}{
+				{
+					"id":     "archive",
+					"status": "Enabled",
+					"transitions": []map[string]interface{}{
+						{"days": 0, "storage_class": "GLACIER_IR"},
+					},
+				},
+			},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify small objects are no longer excluded from every transition
+	assert.Equal(t, "varies_by_storage_class", GetS3BucketTransitionDefaultMinimumObjectSize(t, region, bucketName))
+}
+
+func TestS3BucketLifecycleTagFilter(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-lifecycle-tags"),
+			"lifecycle_rules": []map[string]interface{}{
+				{
+					"id":     "cold-objects",
+					"status": "Enabled",
+					"filter": map[string]interface{}{
+						"tags": []map[string]interface{}{
+							{"key": "class", "value": "cold"},
+						},
+					},
+					"transitions": []map[string]interface{}{
+						{"days": 30, "storage_class": "GLACIER"},
+					},
+				},
+				{
+					"id":     "cold-logs",
+					"status": "Enabled",
+					"filter": map[string]interface{}{
+						"prefix": "logs/",
+						"tags": []map[string]interface{}{
+							{"key": "class", "value": "cold"},
+						},
+					},
+					"transitions": []map[string]interface{}{
+						{"days": 30, "storage_class": "GLACIER"},
+					},
+				},
+				{
+					"id":     "cold-analytics",
+					"status": "Enabled",
+					"filter": map[string]interface{}{
+						"tags": []map[string]interface{}{
+							{"key": "class", "value": "cold"},
+							{"key": "team", "value": "analytics"},
+						},
+					},
+					"transitions": []map[string]interface{}{
+						{"days": 30, "storage_class": "GLACIER"},
+					},
+				},
+				{
+					"id":     "cold-large-archives",
+					"status": "Enabled",
+					"filter": map[string]interface{}{
+						"prefix":                   "archives/",
+						"object_size_greater_than": 131072,
+						"tags": []map[string]interface{}{
+							{"key": "class", "value": "cold"},
+							{"key": "team", "value": "analytics"},
+						},
+					},
+					"transitions": []map[string]interface{}{
+						{"days": 30, "storage_class": "DEEP_ARCHIVE"},
+					},
+				},
+			},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify a lone tag is rendered as a plain tag filter and combined conditions as an and block
+	rules := map[string]*s3.LifecycleRule{}
+	for _, rule := range GetS3BucketLifecycle(t, region, bucketName) {
+		rules[awssdk.StringValue(rule.ID)] = rule
+	}
+	assert.Len(t, rules, 4)
+
+	andTags := func(rule *s3.LifecycleRule) map[string]string {
+		tags := map[string]string{}
+		for _, tag := range rule.Filter.And.Tags {
+			tags[awssdk.StringValue(tag.Key)] = awssdk.StringValue(tag.Value)
+		}
+		return tags
+	}
+
+	if rule, ok := rules["cold-objects"]; assert.True(t, ok) && assert.NotNil(t, rule.Filter.Tag) {
+		assert.Equal(t, "class", awssdk.StringValue(rule.Filter.Tag.Key))
+		assert.Equal(t, "cold", awssdk.StringValue(rule.Filter.Tag.Value))
+		assert.Nil(t, rule.Filter.And)
+	}
+
+	// A prefix with a single tag is two conditions, so it needs an and block too
+	if rule, ok := rules["cold-logs"]; assert.True(t, ok) && assert.NotNil(t, rule.Filter.And) {
+		assert.Nil(t, rule.Filter.Tag)
+		assert.Empty(t, awssdk.StringValue(rule.Filter.Prefix))
+		assert.Equal(t, "logs/", awssdk.StringValue(rule.Filter.And.Prefix))
+		assert.Equal(t, map[string]string{"class": "cold"}, andTags(rule))
+	}
+
+	// Two tags without any other condition are combined in an and block rather than repeated tag filters
+	if rule, ok := rules["cold-analytics"]; assert.True(t, ok) && assert.NotNil(t, rule.Filter.And) {
+		assert.Nil(t, rule.Filter.Tag)
+		assert.Empty(t, awssdk.StringValue(rule.Filter.And.Prefix))
+		assert.Equal(t, map[string]string{"class": "cold", "team": "analytics"}, andTags(rule))
+	}
+
+	if rule, ok := rules["cold-large-archives"]; assert.True(t, ok) && assert.NotNil(t, rule.Filter.And) {
+		assert.Equal(t, "archives/", awssdk.StringValue(rule.Filter.And.Prefix))
+		assert.Equal(t, int64(131072), awssdk.Int64Value(rule.Filter.And.ObjectSizeGreaterThan))
+		assert.Equal(t, map[string]string{"class": "cold", "team": "analytics"}, andTags(rule))
+	}
+}
+
+func TestS3BucketLifecycleTransitionMinimumDays(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	testCases := []struct {
+		name          string
+		transitions   []map[string]interface{}
+		expectedError string
+	}{
+		{
+			"InfrequentAccessTooEarly",
+			[]map[string]interface{}{
+				{"days": 10, "storage_class": "STANDARD_IA"},
+			},
+			"at least 30 days",
+		},
+		{
+			"GlacierTooSoonAfterInfrequentAccess",
+			[]map[string]interface{}{
+				{"days": 30, "storage_class": "STANDARD_IA"},
+				{"days": 40, "storage_class": "GLACIER"},
+			},
+			"30 days later",
+		},
+		{
+			"Accepted",
+			[]map[string]interface{}{
+				{"days": 0, "storage_class": "GLACIER_IR"},
+				{"days": 30, "storage_class": "DEEP_ARCHIVE"},
+			},
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: CopyExampleToTemp(t, "basic"),
+				Vars: map[string]interface{}{
+					"region":      region,
+					"bucket_name": UniqueBucketName("test-transition-days"),
+					"lifecycle_rules": []map[string]interface{}{
+						{
+							"id":          "archive",
+							"status":      "Enabled",
+							"transitions": testCase.transitions,
+						},
+					},
+				},
+				EnvVars: map[string]string{
+					"AWS_DEFAULT_REGION": region,
+				},
+			}
+
+			// Transition days are checked at plan time, so nothing is applied
+			_, err := terraform.InitAndPlanE(t, terraformOptions)
+			if testCase.expectedError == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestS3BucketACL(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":           region,
+			"bucket_name":      UniqueBucketName("test-acl"),
+			"object_ownership": "BucketOwnerPreferred",
+			"acl":              "private",
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the ACL applied alongside the ownership setting that permits it
+	assert.Equal(t, "BucketOwnerPreferred", GetS3BucketOwnershipControls(t, region, bucketName))
+}
+
+func TestS3BucketACLRequiresOwnership(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-acl-enforced"),
+			"acl":         "private",
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	}
+
+	// ACLs are disabled under BucketOwnerEnforced, so the plan must fail
+	_, err := terraform.InitAndPlanE(t, terraformOptions)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "BucketOwnerEnforced")
+	}
+}
+
+func TestS3BucketAnalytics(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "analytics"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-analytics"),
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+	analyticsBucketArn := terraform.Output(t, terraformOptions, "analytics_bucket_arn")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the analysis is filtered to the prefix and exported to the reporting bucket
+	analytics := GetS3BucketAnalytics(t, region, bucketName, "uploads-access-patterns")
+	assert.Equal(t, "uploads/", awssdk.StringValue(analytics.Filter.Prefix))
+	destination := analytics.StorageClassAnalysis.DataExport.Destination.S3BucketDestination
+	assert.Equal(t, analyticsBucketArn, awssdk.StringValue(destination.Bucket))
+	assert.Equal(t, "storage-class-analysis", awssdk.StringValue(destination.Prefix))
+	assert.Equal(t, "CSV", awssdk.StringValue(destination.Format))
+}
+
+func TestS3BucketCrossAccountRead(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Principals must exist, so the second account comes from the environment. Without one, the
+	// test grants the current account, which still exercises the generated statement
+	readerAccountId := os.Getenv("TERRATEST_CROSS_ACCOUNT_ID")
+	if readerAccountId == "" {
+		readerAccountId = aws.GetAccountId(t)
+	}
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":                        region,
+			"bucket_name":                   UniqueBucketName("test-cross-account"),
+			"cross_account_read_principals": []string{readerAccountId},
+			"enforce_ssl":                   true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the read statement names the account, merged with the TLS deny statement
+	policy := aws.GetS3BucketPolicy(t, region, bucketName)
+	assert.Contains(t, policy, "AllowCrossAccountRead")
+	assert.Contains(t, policy, fmt.Sprintf("arn:aws:iam::%s:root", readerAccountId))
+	assert.Contains(t, policy, "DenyInsecureTransport")
+}
+
+func TestS3BucketGovernanceBypassPrincipals(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+	principal := fmt.Sprintf("arn:aws:iam::%s:root", aws.GetAccountId(t))
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":              region,
+			"bucket_name":         UniqueBucketName("test-governance-bypass"),
+			"object_lock_enabled": true,
+			"object_lock_governance_bypass_principals": []string{principal},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+	policyJSON := terraform.Output(t, terraformOptions, "bucket_policy_json")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the bypass statement grants only the given principal on the bucket's objects
+	var policy struct {
+		Statement []struct {
+			Sid       string
+			Effect    string
+			Principal map[string]string
+			Action    string
+			Resource  string
+		}
+	}
+	require.NoError(t, json.Unmarshal([]byte(policyJSON), &policy))
+	require.Len(t, policy.Statement, 1)
+
+	statement := policy.Statement[0]
+	assert.Equal(t, "AllowGovernanceRetentionBypass", statement.Sid)
+	assert.Equal(t, "Allow", statement.Effect)
+	assert.Equal(t, principal, statement.Principal["AWS"])
+	assert.Equal(t, "s3:BypassGovernanceRetention", statement.Action)
+	assert.Equal(t, fmt.Sprintf("arn:aws:s3:::%s/*", bucketName), statement.Resource)
+}
+
+func TestS3BucketPolicyStatements(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":                        region,
+			"bucket_name":                   UniqueBucketName("test-policy-merge"),
+			"enforce_ssl":                   true,
+			"cross_account_read_principals": []string{aws.GetAccountId(t)},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+	policyJSON := terraform.Output(t, terraformOptions, "bucket_policy_json")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the rendered document holds both statements under distinct Sids
+	var policy struct {
+		Statement []struct {
+			Sid string
+		}
+	}
+	require.NoError(t, json.Unmarshal([]byte(policyJSON), &policy))
+
+	sids := []string{}
+	for _, statement := range policy.Statement {
+		sids = append(sids, statement.Sid)
+	}
+	assert.ElementsMatch(t, []string{"DenyInsecureTransport", "AllowCrossAccountRead"}, sids)
+}
+
+func TestS3BucketPolicySidCollision(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+	bucketName := UniqueBucketName("test-policy-sid")
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": bucketName,
+			"enforce_ssl": true,
+			"bucket_policy": fmt.Sprintf(`{
+				"Version": "2012-10-17",
+				"Statement": [{
+					"Sid": "DenyInsecureTransport",
+					"Effect": "Deny",
+					"Principal": "*",
+					"Action": "s3:DeleteBucket",
+					"Resource": "arn:aws:s3:::%s"
+				}]
+			}`, bucketName),
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	}
+
+	// A custom statement reusing a generated Sid would be silently replaced, so the plan must fail
+	_, err := terraform.InitAndPlanE(t, terraformOptions)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Sids")
+	}
+}
+
+func TestS3PublicWebsite(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	indexContent := "<!DOCTYPE html><html><body><h1>Hello from the public website test</h1></body></html>"
+
+	for _, method := range []string{"policy", "acl"} {
+		method := method
+		t.Run(method, func(t *testing.T) {
+			t.Parallel()
+
+			// Each case applies its own copy of the example so it tears down independently
+			terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+				TerraformDir: CopyExampleToTemp(t, "public-website"),
+				Vars: map[string]interface{}{
+					"region":             region,
+					"bucket_name":        UniqueBucketName("test-public-website"),
+					"public_read_method": method,
+					"index_content":      indexContent,
+				},
+				EnvVars: map[string]string{
+					"AWS_DEFAULT_REGION": region,
+				},
+			})
+
+			// Clean up resources
+			defer terraform.Destroy(t, terraformOptions)
+
+			// Run Terraform
+			terraform.InitAndApply(t, terraformOptions)
+
+			// Get outputs
+			bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+			websiteURL := terraform.Output(t, terraformOptions, "website_url")
+
+			// Wait until the bucket is ready before asserting on it
+			eventuallyBucketReady(t, region, bucketName)
+
+			// The website endpoint can return 403 or 404 until the policy or ACLs propagate
+			http_helper.HttpGetWithRetryWithCustomValidation(t, websiteURL, nil, 30, 10*time.Second, func(status int, body string) bool {
+				return status == 200 && strings.Contains(body, indexContent)
+			})
+		})
+	}
+}
+
+func TestS3BucketRejectsUnsupportedEncryption(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	testCases := []struct {
+		name string
+		vars map[string]interface{}
+	}{
+		{"Algorithm", map[string]interface{}{"encryption_algorithm": "SSE-C"}},
+		{"Object", map[string]interface{}{"encryption": map[string]interface{}{"sse_algorithm": "SSE-C"}}},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			vars := map[string]interface{}{
+				"region":      region,
+				"bucket_name": UniqueBucketName("test-sse-c"),
+			}
+			for key, value := range testCase.vars {
+				vars[key] = value
+			}
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: CopyExampleToTemp(t, "basic"),
+				Vars:         vars,
+				EnvVars: map[string]string{
+					"AWS_DEFAULT_REGION": region,
+				},
+			}
+
+			// SSE-C and other unknown algorithms must be rejected at plan time
+			_, err := terraform.InitAndPlanE(t, terraformOptions)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), "SSE-C")
+			}
+		})
+	}
+}
+
+func TestS3BucketPublicAccessConflict(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+	bucketName := UniqueBucketName("test-public-conflict")
+
+	testCases := []struct {
+		name          string
+		vars          map[string]interface{}
+		expectedError string
+	}{
+		{
+			"Policy",
+			map[string]interface{}{
+				"bucket_policy": fmt.Sprintf(`{
+					"Version": "2012-10-17",
+					"Statement": [{
+						"Sid": "PublicReadGetObject",
+						"Effect": "Allow",
+						"Principal": "*",
+						"Action": "s3:GetObject",
+						"Resource": "arn:aws:s3:::%s/*"
+					}]
+				}`, bucketName),
+			},
+			"block_public_policy",
+		},
+		{
+			"ACL",
+			map[string]interface{}{
+				"object_ownership": "BucketOwnerPreferred",
+				"acl":              "public-read",
+			},
+			"block_public_acls",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			vars := map[string]interface{}{
+				"region":      region,
+				"bucket_name": bucketName,
+			}
+			for key, value := range testCase.vars {
+				vars[key] = value
+			}
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: CopyExampleToTemp(t, "basic"),
+				Vars:         vars,
+				EnvVars: map[string]string{
+					"AWS_DEFAULT_REGION": region,
+				},
+			}
+
+			// Public access requested while the public access block still applies must fail at plan time
+			_, err := terraform.InitAndPlanE(t, terraformOptions)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestS3CrossRegion(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+	destinationRegion := GetTestReplicaRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "cross-region"),
+		Vars: map[string]interface{}{
+			"region":             region,
+			"destination_region": destinationRegion,
+			"bucket_name":        UniqueBucketName("test-cross-region"),
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	sourceBucketName := terraform.Output(t, terraformOptions, "source_bucket_name")
+	destinationBucketName := terraform.Output(t, terraformOptions, "destination_bucket_name")
+
+	// Wait until both buckets are ready before asserting on them
+	eventuallyBucketReady(t, region, sourceBucketName)
+	eventuallyBucketReady(t, destinationRegion, destinationBucketName)
+
+	// Verify each bucket was created in the region of the provider it was given
+	assert.Equal(t, region, terraform.Output(t, terraformOptions, "source_bucket_region"))
+	assert.Equal(t, destinationRegion, terraform.Output(t, terraformOptions, "destination_bucket_region"))
+	assert.Equal(t, region, GetS3BucketRegion(t, region, sourceBucketName))
+	assert.Equal(t, destinationRegion, GetS3BucketRegion(t, destinationRegion, destinationBucketName))
+}
+
+func TestS3BatchOperationsManifest(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "batch-operations-manifest"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-batch-manifests"),
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify manifests and reports expire under their prefixes
+	rules := map[string]*s3.LifecycleRule{}
+	for _, rule := range GetS3BucketLifecycle(t, region, bucketName) {
+		rules[awssdk.StringValue(rule.ID)] = rule
+	}
+
+	if rule, ok := rules["expire-manifests"]; assert.True(t, ok) && assert.NotNil(t, rule.Expiration) {
+		assert.Equal(t, "manifests/", awssdk.StringValue(rule.Filter.Prefix))
+		assert.Equal(t, int64(7), awssdk.Int64Value(rule.Expiration.Days))
+	}
+
+	if rule, ok := rules["expire-reports"]; assert.True(t, ok) && assert.NotNil(t, rule.Expiration) {
+		assert.Equal(t, "reports/", awssdk.StringValue(rule.Filter.Prefix))
+		assert.Equal(t, int64(30), awssdk.Int64Value(rule.Expiration.Days))
+	}
+
+	if rule, ok := rules["abort-incomplete-uploads"]; assert.True(t, ok) && assert.NotNil(t, rule.AbortIncompleteMultipartUpload) {
+		assert.Equal(t, int64(1), awssdk.Int64Value(rule.AbortIncompleteMultipartUpload.DaysAfterInitiation))
+	}
+}
+
+func TestS3BucketSecurityProfile(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	testCases := []struct {
+		profile     string
+		algorithm   string
+		objectLock  string
+		expectedSid []string
+	}{
+		{"baseline", "AES256", "false", []string{"DenyInsecureTransport"}},
+		{"strict", "aws:kms", "true", []string{"DenyInsecureTransport", "DenyOutdatedTLS"}},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.profile, func(t *testing.T) {
+			t.Parallel()
+
+			// Each case applies its own copy of the example so it tears down independently
+			terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+				TerraformDir: CopyExampleToTemp(t, "basic"),
+				Vars: map[string]interface{}{
+					"region":           region,
+					"bucket_name":      UniqueBucketName("test-profile-" + testCase.profile),
+					"security_profile": testCase.profile,
+				},
+				EnvVars: map[string]string{
+					"AWS_DEFAULT_REGION": region,
+				},
+			})
+
+			// Clean up resources
+			defer terraform.Destroy(t, terraformOptions)
+
+			// Run Terraform
+			terraform.InitAndApply(t, terraformOptions)
+
+			// Get outputs
+			bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+			policyJSON := terraform.Output(t, terraformOptions, "bucket_policy_json")
+
+			// Wait until the bucket is ready before asserting on it
+			eventuallyBucketReady(t, region, bucketName)
+
+			// Verify the profile picked the encryption, and for strict a module-managed key
+			encryption := GetS3BucketEncryption(t, region, bucketName)
+			if assert.Len(t, encryption.Rules, 1) {
+				sse := encryption.Rules[0].ApplyServerSideEncryptionByDefault
+				assert.Equal(t, testCase.algorithm, awssdk.StringValue(sse.SSEAlgorithm))
+				if testCase.algorithm == "aws:kms" {
+					assert.Equal(t, terraform.Output(t, terraformOptions, "kms_key_arn"), awssdk.StringValue(sse.KMSMasterKeyID))
+				}
+			}
+			assert.Equal(t, testCase.objectLock, terraform.Output(t, terraformOptions, "object_lock_enabled"))
+
+			// Verify the profile added the TLS deny statements
+			var policy struct {
+				Statement []struct {
+					Sid string
+				}
+			}
+			require.NoError(t, json.Unmarshal([]byte(policyJSON), &policy))
+
+			sids := []string{}
+			for _, statement := range policy.Statement {
+				sids = append(sids, statement.Sid)
+			}
+			assert.ElementsMatch(t, testCase.expectedSid, sids)
+		})
+	}
+}
+
+func TestS3BucketSecurityProfileOverride(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	terraformDir := CopyExampleToTemp(t, "basic")
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: terraformDir,
+		PlanFilePath: filepath.Join(terraformDir, "plan.out"),
+		Vars: map[string]interface{}{
+			"region":               region,
+			"bucket_name":          UniqueBucketName("test-profile-override"),
+			"security_profile":     "strict",
+			"encryption_algorithm": "AES256",
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	}
+
+	// The explicit algorithm wins over the profile, so no key is planned, while unset settings keep the strict defaults
+	plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+	_, hasKMSKey := plan.ResourcePlannedValuesMap["module.s3_bucket.aws_kms_key.this[0]"]
+	assert.False(t, hasKMSKey)
+
+	terraform.RequirePlannedValuesMapKeyExists(t, plan, "module.s3_bucket.aws_s3_bucket.this[0]")
+	bucket := plan.ResourcePlannedValuesMap["module.s3_bucket.aws_s3_bucket.this[0]"]
+	assert.Equal(t, true, bucket.AttributeValues["object_lock_enabled"])
+}
+
+func TestS3GovernedData(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "governed-data"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-governed"),
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+	kmsKeyArn := terraform.Output(t, terraformOptions, "kms_key_arn")
+	logBucketName := terraform.Output(t, terraformOptions, "log_bucket_name")
+	targetPrefix := terraform.Output(t, terraformOptions, "logging_target_prefix")
+	inventoryBucketName := terraform.Output(t, terraformOptions, "inventory_bucket_name")
+	inventoryBucketArn := terraform.Output(t, terraformOptions, "inventory_bucket_arn")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Access logs and inventory reports may be delivered before teardown
+	defer aws.EmptyS3Bucket(t, region, logBucketName)
+	defer aws.EmptyS3Bucket(t, region, inventoryBucketName)
+
+	// Verify the bucket is private and versioned
+	publicAccessBlock := GetS3BucketPublicAccessBlock(t, region, bucketName)
+	assert.True(t, awssdk.BoolValue(publicAccessBlock.BlockPublicAcls))
+	assert.True(t, awssdk.BoolValue(publicAccessBlock.BlockPublicPolicy))
+	assert.True(t, awssdk.BoolValue(publicAccessBlock.IgnorePublicAcls))
+	assert.True(t, awssdk.BoolValue(publicAccessBlock.RestrictPublicBuckets))
+	assert.Equal(t, "Enabled", aws.GetS3BucketVersioning(t, region, bucketName))
+
+	// Verify default encryption uses the module-managed KMS key
+	encryption := GetS3BucketEncryption(t, region, bucketName)
+	if assert.NotEmpty(t, encryption.Rules) {
+		defaultEncryption := encryption.Rules[0].ApplyServerSideEncryptionByDefault
+		assert.Equal(t, "aws:kms", awssdk.StringValue(defaultEncryption.SSEAlgorithm))
+		assert.Equal(t, kmsKeyArn, awssdk.StringValue(defaultEncryption.KMSMasterKeyID))
+		assert.True(t, awssdk.BoolValue(encryption.Rules[0].BucketKeyEnabled))
+	}
+
+	// Verify access logs go to the log bucket
+	assert.Equal(t, logBucketName, aws.GetS3BucketLoggingTarget(t, region, bucketName))
+	assert.Equal(t, targetPrefix, aws.GetS3BucketLoggingTargetPrefix(t, region, bucketName))
+
+	// Verify the daily inventory is delivered to the inventory bucket
+	inventory := GetS3BucketInventory(t, region, bucketName, "daily-governance")
+	assert.True(t, awssdk.BoolValue(inventory.IsEnabled))
+	assert.Equal(t, "All", awssdk.StringValue(inventory.IncludedObjectVersions))
+	assert.Equal(t, "Daily", awssdk.StringValue(inventory.Schedule.Frequency))
+	assert.Equal(t, inventoryBucketArn, awssdk.StringValue(inventory.Destination.S3BucketDestination.Bucket))
+}
+
+func TestS3PublicAccessEnforced(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-public-blocked"),
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify every public access block flag is set
+	publicAccessBlock := GetS3BucketPublicAccessBlock(t, region, bucketName)
+	require.True(t, awssdk.BoolValue(publicAccessBlock.BlockPublicPolicy))
+	require.True(t, awssdk.BoolValue(publicAccessBlock.RestrictPublicBuckets))
+
+	// Verify S3 rejects a public-read policy written outside Terraform
+	publicPolicy := fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Sid":"PublicRead","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::%s/*"}]}`, bucketName)
+	err := aws.PutS3BucketPolicyE(t, region, bucketName, publicPolicy)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "AccessDenied")
+	}
+
+	// Verify the bucket still has no policy
+	_, err = aws.GetS3BucketPolicyE(t, region, bucketName)
+	assert.Error(t, err)
+}
+
+func TestS3KMSCrossAccountReplication(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// The destination bucket and replica key must exist in a second account, with policies trusting
+	// this account. Without them the test only checks the rendered plan
+	destinationAccountId := os.Getenv("TERRATEST_CROSS_ACCOUNT_ID")
+	destinationBucketArn := os.Getenv("TERRATEST_CROSS_ACCOUNT_DESTINATION_BUCKET_ARN")
+	replicaKMSKeyArn := os.Getenv("TERRATEST_CROSS_ACCOUNT_REPLICA_KMS_KEY_ARN")
+	secondAccountConfigured := destinationAccountId != "" && destinationBucketArn != "" && replicaKMSKeyArn != ""
+	if !secondAccountConfigured {
+		destinationAccountId = "111111111111"
+		destinationBucketArn = "arn:aws:s3:::cross-account-replica-placeholder"
+		replicaKMSKeyArn = "arn:aws:kms:us-west-2:111111111111:key/00000000-0000-0000-0000-000000000000"
+	}
+
+	vars := map[string]interface{}{
+		"region":                 region,
+		"bucket_name":            UniqueBucketName("test-xaccount-replication"),
+		"destination_account_id": destinationAccountId,
+		"destination_bucket_arn": destinationBucketArn,
+		"replica_kms_key_arn":    replicaKMSKeyArn,
+	}
+
+	terraformDir := CopyExampleToTemp(t, "kms-cross-account-replication")
+
+	planOptions := &terraform.Options{
+		TerraformDir: terraformDir,
+		PlanFilePath: filepath.Join(terraformDir, "plan.out"),
+		Vars:         vars,
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	}
+
+	// Verify the rule targets the destination account, its key and KMS-encrypted source objects
+	plan := terraform.InitAndPlanAndShowWithStruct(t, planOptions)
+	terraform.RequirePlannedValuesMapKeyExists(t, plan, "module.s3_source.aws_s3_bucket_replication_configuration.this[0]")
+	replicationPlan := plan.ResourcePlannedValuesMap["module.s3_source.aws_s3_bucket_replication_configuration.this[0]"]
+
+	rules := replicationPlan.AttributeValues["rule"].([]interface{})
+	require.Len(t, rules, 1)
+	rule := rules[0].(map[string]interface{})
+	destination := rule["destination"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, destinationBucketArn, destination["bucket"])
+	assert.Equal(t, destinationAccountId, destination["account"])
+	assert.Equal(t, "Destination", destination["access_control_translation"].([]interface{})[0].(map[string]interface{})["owner"])
+	assert.Equal(t, replicaKMSKeyArn, destination["encryption_configuration"].([]interface{})[0].(map[string]interface{})["replica_kms_key_id"])
+	sourceSelection := rule["source_selection_criteria"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "Enabled", sourceSelection["sse_kms_encrypted_objects"].([]interface{})[0].(map[string]interface{})["status"])
+
+	if !secondAccountConfigured {
+		t.Skip("Set TERRATEST_CROSS_ACCOUNT_ID, TERRATEST_CROSS_ACCOUNT_DESTINATION_BUCKET_ARN and TERRATEST_CROSS_ACCOUNT_REPLICA_KMS_KEY_ARN to apply the example")
+	}
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: terraformDir,
+		Vars:         vars,
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.Apply(t, terraformOptions)
+
+	// Get outputs
+	sourceBucketName := terraform.Output(t, terraformOptions, "source_bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, sourceBucketName)
+
+	// Verify the applied rule uses the replica key from the destination account
+	replication := GetS3BucketReplication(t, region, sourceBucketName)
+	require.Len(t, replication.Rules, 1)
+	appliedDestination := replication.Rules[0].Destination
+	assert.Equal(t, destinationBucketArn, awssdk.StringValue(appliedDestination.Bucket))
+	assert.Equal(t, destinationAccountId, awssdk.StringValue(appliedDestination.Account))
+	assert.Equal(t, "Destination", awssdk.StringValue(appliedDestination.AccessControlTranslation.Owner))
+	assert.Equal(t, replicaKMSKeyArn, awssdk.StringValue(appliedDestination.EncryptionConfiguration.ReplicaKmsKeyID))
+	assert.Equal(t, "Enabled", awssdk.StringValue(replication.Rules[0].SourceSelectionCriteria.SseKmsEncryptedObjects.Status))
+}
+
+func TestS3BucketDenyUnencryptedUploads(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":                        region,
+			"bucket_name":                   UniqueBucketName("test-deny-unencrypted"),
+			"deny_unencrypted_uploads":      true,
+			"required_upload_sse_algorithm": "AES256",
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Objects written during the test must be removed before the bucket is destroyed
+	defer aws.EmptyS3Bucket(t, region, bucketName)
+
+	// Verify uploads without the header, or with another algorithm, are rejected. Policy changes can take a
+	// moment to reach every endpoint, so retry until the denial is observed
+	retry.DoWithRetry(t, "Wait for unencrypted uploads to be denied", 12, 5*time.Second, func() (string, error) {
+		err := PutS3ObjectContentsWithEncryptionE(t, region, bucketName, "unencrypted.txt", "hello", "")
+		if err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+			return "", fmt.Errorf("expected AccessDenied for an unencrypted upload, got %v", err)
+		}
+		return "", nil
+	})
+
+	err := PutS3ObjectContentsWithEncryptionE(t, region, bucketName, "kms.txt", "hello", "aws:kms")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "AccessDenied")
+
+	// Verify an upload requesting the required algorithm succeeds
+	require.NoError(t, PutS3ObjectContentsWithEncryptionE(t, region, bucketName, "encrypted.txt", "hello", "AES256"))
+}
+
+func TestS3BucketAbortIncompleteMultipartUploadDaysVariable(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":                                 region,
+			"bucket_name":                            UniqueBucketName("test-abort-multipart"),
+			"abort_incomplete_multipart_upload_days": 3,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the generated rule is the only one and aborts uploads across the whole bucket
+	rules := GetS3BucketLifecycle(t, region, bucketName)
+	require.Len(t, rules, 1)
+
+	rule := rules[0]
+	assert.Equal(t, "abort-incomplete-multipart-upload", awssdk.StringValue(rule.ID))
+	assert.Equal(t, "Enabled", awssdk.StringValue(rule.Status))
+	require.NotNil(t, rule.AbortIncompleteMultipartUpload)
+	assert.Equal(t, int64(3), awssdk.Int64Value(rule.AbortIncompleteMultipartUpload.DaysAfterInitiation))
+	if rule.Filter != nil {
+		assert.Empty(t, awssdk.StringValue(rule.Filter.Prefix))
+	}
+	assert.Nil(t, rule.Expiration)
+	assert.Empty(t, rule.Transitions)
+}
+
+func TestS3BucketKMSKeyLookupAlias(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+	bucketName := UniqueBucketName("test-kms-alias")
+
+	// Create a key outside the module and reference it only by alias
+	kmsKeyArn := CreateKMSKey(t, region, "terratest alias lookup key for S3")
+	defer ScheduleKMSKeyDeletion(t, region, kmsKeyArn)
+
+	alias := fmt.Sprintf("alias/%s", bucketName)
+	CreateKMSAlias(t, region, alias, kmsKeyArn)
+	defer DeleteKMSAlias(t, region, alias)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":               region,
+			"bucket_name":          bucketName,
+			"encryption_algorithm": "aws:kms",
+			"kms_key_alias":        alias,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the bucket default encryption uses the key behind the alias
+	encryption := GetS3BucketEncryption(t, region, bucketName)
+	require.Len(t, encryption.Rules, 1)
+	defaults := encryption.Rules[0].ApplyServerSideEncryptionByDefault
+	assert.Equal(t, "aws:kms", awssdk.StringValue(defaults.SSEAlgorithm))
+	assert.Equal(t, kmsKeyArn, awssdk.StringValue(defaults.KMSMasterKeyID))
+	assert.Equal(t, kmsKeyArn, terraform.Output(t, terraformOptions, "kms_key_arn"))
+
+	// Objects written during the test must be removed before the bucket is destroyed
+	defer aws.EmptyS3Bucket(t, region, bucketName)
+
+	// Verify an upload without encryption headers is encrypted with the resolved key
+	output, _ := PutAndGetObject(t, region, bucketName, "encrypted.txt", "hello")
+	assert.Equal(t, kmsKeyArn, awssdk.StringValue(output.SSEKMSKeyId))
+}
+
+func TestS3VersioningTransition(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	terraformDir := CopyExampleToTemp(t, "basic")
+	bucketName := UniqueBucketName("test-versioning-transition")
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: terraformDir,
+		Vars: map[string]interface{}{
+			"region":            region,
+			"bucket_name":       bucketName,
+			"versioning_status": "Enabled",
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketArn := terraform.Output(t, terraformOptions, "bucket_arn")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify versioning starts enabled
+	assert.Equal(t, "Enabled", aws.GetS3BucketVersioning(t, region, bucketName))
+
+	// Plan the change against the same state; the bucket itself must not change and versioning updates in place
+	planOptions := &terraform.Options{
+		TerraformDir: terraformDir,
+		PlanFilePath: filepath.Join(terraformDir, "plan.out"),
+		Vars: map[string]interface{}{
+			"region":            region,
+			"bucket_name":       bucketName,
+			"versioning_status": "Suspended",
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	}
+	plan := terraform.InitAndPlanAndShowWithStruct(t, planOptions)
+
+	bucketChange, ok := plan.ResourceChangesMap["module.s3_bucket.aws_s3_bucket.this[0]"]
+	require.True(t, ok)
+	assert.True(t, bucketChange.Change.Actions.NoOp())
+
+	versioningChange, ok := plan.ResourceChangesMap["module.s3_bucket.aws_s3_bucket_versioning.this[0]"]
+	require.True(t, ok)
+	assert.True(t, versioningChange.Change.Actions.Update())
+
+	// Re-apply with the suspended status using the same state
+	terraformOptions.Vars["versioning_status"] = "Suspended"
+	terraform.Apply(t, terraformOptions)
+
+	// Verify the status changed and the bucket was not replaced
+	assert.Equal(t, "Suspended", aws.GetS3BucketVersioning(t, region, bucketName))
+	assert.Equal(t, "Suspended", terraform.Output(t, terraformOptions, "bucket_versioning_status"))
+	assert.Equal(t, bucketName, terraform.Output(t, terraformOptions, "bucket_name"))
+	assert.Equal(t, bucketArn, terraform.Output(t, terraformOptions, "bucket_arn"))
+}
+
+func TestS3BucketObjectLockWithoutDefaultRetention(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":              region,
+			"bucket_name":         UniqueBucketName("test-legal-hold"),
+			"object_lock_enabled": true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify object lock is enabled without a default retention rule, leaving protection to per-object legal holds
+	assert.Equal(t, "true", terraform.Output(t, terraformOptions, "object_lock_enabled"))
+	objectLockConfig := GetS3BucketObjectLockConfiguration(t, region, bucketName)
+	require.NotNil(t, objectLockConfig)
+	assert.Equal(t, "Enabled", awssdk.StringValue(objectLockConfig.ObjectLockEnabled))
+	assert.Nil(t, objectLockConfig.Rule)
+
+	// Verify the module did not manage an object lock configuration
+	stateList, err := terraform.RunTerraformCommandE(t, terraformOptions, "state", "list")
+	require.NoError(t, err)
+	assert.NotContains(t, stateList, "aws_s3_bucket_object_lock_configuration")
+}
+
+func TestS3BucketKeyToggle(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	testCases := []struct {
+		name             string
+		bucketKeyEnabled bool
+	}{
+		{"enabled", true},
+		{"disabled", false},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Each case applies its own copy of the example so it tears down independently
+			terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+				TerraformDir: CopyExampleToTemp(t, "basic"),
+				Vars: map[string]interface{}{
+					"region":               region,
+					"bucket_name":          UniqueBucketName("test-bucket-key"),
+					"encryption_algorithm": "aws:kms",
+					"create_kms_key":       true,
+					"bucket_key_enabled":   testCase.bucketKeyEnabled,
+				},
+				EnvVars: map[string]string{
+					"AWS_DEFAULT_REGION": region,
+				},
+			})
+
+			// Clean up resources
+			defer terraform.Destroy(t, terraformOptions)
+
+			// Run Terraform
+			terraform.InitAndApply(t, terraformOptions)
+
+			// Get outputs
+			bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+			// Wait until the bucket is ready before asserting on it
+			eventuallyBucketReady(t, region, bucketName)
+
+			// Verify the default encryption rule and the output reflect the bucket key setting
+			encryption := GetS3BucketEncryption(t, region, bucketName)
+			require.Len(t, encryption.Rules, 1)
+			rule := encryption.Rules[0]
+			assert.Equal(t, "aws:kms", awssdk.StringValue(rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm))
+			assert.Equal(t, testCase.bucketKeyEnabled, awssdk.BoolValue(rule.BucketKeyEnabled))
+			assert.Equal(t, fmt.Sprintf("%t", testCase.bucketKeyEnabled), terraform.Output(t, terraformOptions, "bucket_key_enabled"))
+		})
+	}
+}
+
+func TestS3ForceDestroyVersioned(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options. The basic example sets force_destroy and keeps versioning enabled
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":            region,
+			"bucket_name":       UniqueBucketName("test-force-destroy"),
+			"versioning_status": "Enabled",
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources if the destroy under test fails part way
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Write several versions of two keys, one of them hidden behind a delete marker
+	PutS3ObjectVersionsWithDeleteMarker(t, region, bucketName, "deleted.txt", 3)
+	for i := 0; i < 3; i++ {
+		PutS3ObjectContents(t, region, bucketName, "current.txt", fmt.Sprintf("version %d", i))
+	}
+
+	versions, deleteMarkers := CountS3ObjectVersions(t, region, bucketName)
+	assert.Equal(t, 6, versions)
+	assert.Equal(t, 1, deleteMarkers)
+
+	// Verify destroy removes every version and delete marker along with the bucket
+	terraform.Destroy(t, terraformOptions)
+	assert.Error(t, aws.AssertS3BucketExistsE(t, region, bucketName))
+}
+
+func TestS3BucketConsolidatedOutput(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-bucket-output"),
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify every field of the consolidated object matches its scalar output
+	var bucket map[string]string
+	terraform.OutputStruct(t, terraformOptions, "bucket", &bucket)
+	assert.Equal(t, map[string]string{
+		"id":                   bucketName,
+		"arn":                  terraform.Output(t, terraformOptions, "bucket_arn"),
+		"domain_name":          terraform.Output(t, terraformOptions, "bucket_domain_name"),
+		"regional_domain_name": terraform.Output(t, terraformOptions, "bucket_regional_domain_name"),
+		"hosted_zone_id":       terraform.Output(t, terraformOptions, "bucket_hosted_zone_id"),
+		"region":               terraform.Output(t, terraformOptions, "bucket_region"),
+	}, bucket)
+	assert.Equal(t, region, bucket["region"])
+}
+
+func TestS3BucketCorsRuleOrder(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options. The rules are deliberately not in alphabetical order
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":      region,
+			"bucket_name": UniqueBucketName("test-cors-order"),
+			"cors_rules": []map[string]interface{}{
+				{
+					"id":              "admin-console",
+					"allowed_headers": []string{"*"},
+					"allowed_methods": []string{"PUT", "POST", "DELETE"},
+					"allowed_origins": []string{"https://admin.example.com"},
+				},
+				{
+					"id":              "app-uploads",
+					"allowed_headers": []string{"Content-Type"},
+					"allowed_methods": []string{"PUT"},
+					"allowed_origins": []string{"https://app.example.com"},
+					"expose_headers":  []string{"ETag"},
+				},
+				{
+					"id":              "catch-all-reads",
+					"allowed_headers": []string{},
+					"allowed_methods": []string{"GET", "HEAD"},
+					"allowed_origins": []string{"*"},
+					"max_age_seconds": 600,
+				},
+			},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the rules come back in input order with their ids
+	corsRules := GetS3BucketCors(t, region, bucketName)
+	require.Len(t, corsRules, 3)
+
+	ids := []string{}
+	origins := []string{}
+	for _, rule := range corsRules {
+		ids = append(ids, awssdk.StringValue(rule.ID))
+		origins = append(origins, strings.Join(awssdk.StringValueSlice(rule.AllowedOrigins), ","))
+	}
+	assert.Equal(t, []string{"admin-console", "app-uploads", "catch-all-reads"}, ids)
+	assert.Equal(t, []string{"https://admin.example.com", "https://app.example.com", "*"}, origins)
+	assert.Equal(t, int64(600), awssdk.Int64Value(corsRules[2].MaxAgeSeconds))
+}
+
+func TestS3BucketUnmanagedPublicAccessBlock(t *testing.T) {
+	t.Parallel()
+
+	region := GetTestRegion(t)
+
+	// Configure Terraform options
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: CopyExampleToTemp(t, "basic"),
+		Vars: map[string]interface{}{
+			"region":                     region,
+			"bucket_name":                UniqueBucketName("test-unmanaged-pab"),
+			"manage_public_access_block": false,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// Clean up resources
+	defer terraform.Destroy(t, terraformOptions)
+
+	// Run Terraform
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Get outputs
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+
+	// Wait until the bucket is ready before asserting on it
+	eventuallyBucketReady(t, region, bucketName)
+
+	// Verify the module created no bucket-level public access block
+	stateList, err := terraform.RunTerraformCommandE(t, terraformOptions, "state", "list")
+	require.NoError(t, err)
+	assert.Contains(t, stateList, "module.s3_bucket.aws_s3_bucket.this[0]")
+	assert.NotContains(t, stateList, "aws_s3_bucket_public_access_block")
+
+	// Verify outputs that read the block resolve without it
+	var featureSummary map[string]bool
+	terraform.OutputStruct(t, terraformOptions, "feature_summary", &featureSummary)
+	assert.False(t, featureSummary["public_access_fully_blocked"])
+}
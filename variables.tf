@@ -1,12 +1,82 @@
 # S3 Bucket Module Variables
 
+variable "create" {
+  description = "Whether to create the bucket and all of its related resources"
+  type        = bool
+  default     = true
+}
+
 variable "bucket_name" {
-  description = "The name of the S3 bucket"
+  description = "The name of the S3 bucket. Conflicts with bucket_prefix"
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.bucket_name == null || try(length(var.bucket_name) >= 3 && length(var.bucket_name) <= 63, false)
+    error_message = "Bucket name must be between 3 and 63 characters long."
+  }
+
+  validation {
+    condition     = var.bucket_name == null || can(regex("^[a-z0-9.-]+$", var.bucket_name))
+    error_message = "Bucket name can only contain lowercase letters, numbers, dots, and hyphens."
+  }
+
+  validation {
+    condition     = var.bucket_name == null || can(regex("^[a-z0-9](.*[a-z0-9])?$", var.bucket_name))
+    error_message = "Bucket name must start and end with a letter or number."
+  }
+
+  validation {
+    condition     = var.bucket_name == null || try(!strcontains(var.bucket_name, ".."), false)
+    error_message = "Bucket name must not contain two adjacent dots."
+  }
+
+  validation {
+    condition     = var.bucket_name == null || !can(regex("^[0-9]+\\.[0-9]+\\.[0-9]+\\.[0-9]+$", var.bucket_name))
+    error_message = "Bucket name must not be formatted as an IP address."
+  }
+
+  validation {
+    condition     = var.bucket_name == null || try(!startswith(var.bucket_name, "xn--") && !startswith(var.bucket_name, "sthree-") && !endswith(var.bucket_name, "-s3alias") && !endswith(var.bucket_name, "--ol-s3"), false)
+    error_message = "Bucket name must not start with 'xn--' or 'sthree-', or end with '-s3alias' or '--ol-s3'."
+  }
+
+  validation {
+    condition     = (var.bucket_name == null) != (var.bucket_prefix == null)
+    error_message = "Exactly one of bucket_name or bucket_prefix must be set."
+  }
+}
+
+variable "bucket_prefix" {
+  description = "Prefix for a bucket name generated by AWS with a unique suffix. Conflicts with bucket_name"
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.bucket_prefix == null || can(regex("^[a-z0-9][a-z0-9.-]{0,36}$", var.bucket_prefix))
+    error_message = "Bucket prefix must be at most 37 characters, start with a letter or number, and contain only lowercase letters, numbers, dots, and hyphens."
+  }
+}
+
+variable "expected_region" {
+  description = "Region the bucket must be created in. When set, the plan fails if the provider is configured for a different region"
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.expected_region == null || can(regex("^[a-z]{2}(-[a-z]+)+-\\d$", var.expected_region))
+    error_message = "expected_region must be an AWS region name, for example us-east-1."
+  }
+}
+
+variable "expected_bucket_owner" {
+  description = "Account ID expected to own the bucket. Passed to the bucket configuration resources that support it, so S3 rejects their requests when the bucket belongs to another account"
   type        = string
+  default     = null
 
   validation {
-    condition     = can(regex("^[a-z0-9][a-z0-9.-]*[a-z0-9]$", var.bucket_name))
-    error_message = "Bucket name must be between 3 and 63 characters long, contain only lowercase letters, numbers, dots, and hyphens, and start and end with a letter or number."
+    condition     = var.expected_bucket_owner == null || can(regex("^[0-9]{12}$", var.expected_bucket_owner))
+    error_message = "expected_bucket_owner must be a 12-digit AWS account ID."
   }
 }
 
@@ -32,31 +102,98 @@ variable "purpose" {
   }
 }
 
+variable "default_tags" {
+  description = "Default tags to apply to all resources. Per-bucket tags and the module's computed tags take precedence"
+  type        = map(string)
+  default     = {}
+}
+
 variable "common_tags" {
-  description = "Common tags to apply to all resources"
+  description = "Deprecated: use default_tags. Merged at the same precedence as default_tags, which wins when both set a key"
   type        = map(string)
   default     = {}
 }
 
-variable "enable_versioning" {
-  description = "Enable versioning for the S3 bucket"
-  type        = bool
-  default     = true
+variable "tags" {
+  description = "Per-bucket tags merged over default_tags and the module's computed tags. Keys set here take precedence"
+  type        = map(string)
+  default     = {}
+}
+
+variable "versioning_status" {
+  description = "Versioning status for the S3 bucket (Enabled, Suspended or Disabled). Disabled skips the versioning configuration and only applies to buckets that were never versioned"
+  type        = string
+  default     = "Enabled"
+
+  validation {
+    condition     = contains(["Enabled", "Suspended", "Disabled"], var.versioning_status)
+    error_message = "Versioning status must be one of: Enabled, Suspended, Disabled."
+  }
+}
+
+variable "mfa_delete" {
+  description = "Whether MFA Delete is enabled for the bucket versioning configuration (Enabled or Disabled). Can only be changed by the root account with its MFA device"
+  type        = string
+  default     = "Disabled"
+
+  validation {
+    condition     = contains(["Enabled", "Disabled"], var.mfa_delete)
+    error_message = "MFA delete must be either 'Enabled' or 'Disabled'."
+  }
+}
+
+variable "mfa" {
+  description = "The root account MFA device serial number and current token, separated by a space, required to change mfa_delete"
+  type        = string
+  default     = null
+  sensitive   = true
+
+  validation {
+    condition     = var.mfa_delete != "Enabled" || var.mfa != null
+    error_message = "The mfa variable (\"<serial> <token>\") must be set when mfa_delete is 'Enabled'."
+  }
+}
+
+variable "security_profile" {
+  description = "Hardening preset. baseline enforces TLS on top of the default versioning, AES256 encryption and public access blocks; strict adds a module-managed KMS key, object lock and a TLS 1.2 minimum. Variables set explicitly take precedence"
+  type        = string
+  default     = "none"
+
+  validation {
+    condition     = contains(["none", "baseline", "strict"], var.security_profile)
+    error_message = "security_profile must be one of 'none', 'baseline' or 'strict'."
+  }
 }
 
 variable "encryption_algorithm" {
-  description = "The server-side encryption algorithm to use"
+  description = "The server-side encryption algorithm to use. Defaults to AES256, or aws:kms with the strict security_profile"
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.encryption_algorithm == null || contains(["AES256", "aws:kms"], var.encryption_algorithm)
+    error_message = "Encryption algorithm must be either 'AES256' or 'aws:kms'. SSE-C cannot be a bucket default; customer-provided keys are sent with each request."
+  }
+}
+
+variable "kms_key_arn" {
+  description = "ARN of an existing KMS key for SSE-KMS (required when encryption_algorithm is 'aws:kms' and create_kms_key is false). The key policy must let the principals writing to the bucket use the key"
   type        = string
-  default     = "AES256"
+  default     = null
+
+  validation {
+    condition     = var.kms_key_arn == null || can(regex("^arn:aws[a-z-]*:kms:", var.kms_key_arn))
+    error_message = "kms_key_arn must be a valid KMS key ARN, such as 'arn:aws:kms:...' or 'arn:aws-us-gov:kms:...'."
+  }
 
   validation {
-    condition     = contains(["AES256", "aws:kms"], var.encryption_algorithm)
-    error_message = "Encryption algorithm must be either 'AES256' or 'aws:kms'."
+    condition     = var.kms_key_arn == null || var.kms_key_id == null
+    error_message = "Only one of kms_key_arn or the deprecated kms_key_id may be set."
   }
 }
 
 variable "kms_key_id" {
-  description = "The KMS master key ID for encryption (required when encryption_algorithm is 'aws:kms')"
+  description = "Deprecated: use kms_key_arn. The KMS master key ARN for encryption. Cannot be set together with kms_key_arn"
   type        = string
   default     = null
 
@@ -66,10 +203,37 @@ variable "kms_key_id" {
   }
 }
 
+variable "kms_key_alias" {
+  description = "Alias of an existing KMS key to use for SSE-KMS, such as alias/prod-data. The key ARN is looked up at plan time. Cannot be combined with kms_key_arn, create_kms_key or encryption"
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.kms_key_alias == null || can(regex("^alias/[a-zA-Z0-9/_-]+$", var.kms_key_alias))
+    error_message = "kms_key_alias must start with 'alias/' followed by letters, numbers, '/', '_' or '-'."
+  }
+
+  validation {
+    condition     = var.kms_key_alias == null || (var.kms_key_arn == null && var.kms_key_id == null && var.create_kms_key != true && var.encryption == null)
+    error_message = "Only one of kms_key_arn, kms_key_alias or create_kms_key may select the KMS key, and kms_key_alias cannot be combined with encryption."
+  }
+}
+
 variable "bucket_key_enabled" {
-  description = "Whether or not to use Amazon S3 Bucket Keys for SSE-KMS"
+  description = "Whether or not to use Amazon S3 Bucket Keys for SSE-KMS. Ignored when encryption_algorithm is 'AES256'"
   type        = bool
-  default     = false
+  default     = true
+
+  validation {
+    condition     = var.bucket_key_enabled != null
+    error_message = "bucket_key_enabled must be true or false. Null would leave the setting to the AWS default instead of the module's."
+  }
+}
+
+variable "manage_public_access_block" {
+  description = "Whether to manage the bucket-level public access block. Set to false where the block is enforced at the account level or by another owner; the block_public_* settings are then ignored"
+  type        = bool
+  default     = true
 }
 
 variable "block_public_acls" {
@@ -97,9 +261,9 @@ variable "restrict_public_buckets" {
 }
 
 variable "object_ownership" {
-  description = "Object ownership setting for the bucket"
+  description = "Object ownership setting for the bucket. BucketOwnerEnforced disables ACLs; use BucketOwnerPreferred or ObjectWriter when an ACL is required"
   type        = string
-  default     = "BucketOwnerPreferred"
+  default     = "BucketOwnerEnforced"
 
   validation {
     condition     = contains(["BucketOwnerPreferred", "BucketOwnerEnforced", "ObjectWriter"], var.object_ownership)
@@ -118,10 +282,38 @@ variable "acl" {
   }
 }
 
+variable "grants" {
+  description = "Explicit ACL grants, used instead of a canned acl. CanonicalUser grantees take grantee_id, Group grantees take uri. The bucket owner always keeps FULL_CONTROL"
+  type = list(object({
+    grantee_type = string
+    grantee_id   = optional(string)
+    uri          = optional(string)
+    permission   = string
+  }))
+  default = []
+
+  validation {
+    condition     = alltrue([for grant in var.grants : contains(["READ", "WRITE", "READ_ACP", "WRITE_ACP", "FULL_CONTROL"], grant.permission)])
+    error_message = "Grant permission must be one of: READ, WRITE, READ_ACP, WRITE_ACP, FULL_CONTROL."
+  }
+
+  validation {
+    condition = alltrue([
+      for grant in var.grants : (grant.grantee_type == "CanonicalUser" && grant.grantee_id != null && grant.uri == null) || (grant.grantee_type == "Group" && grant.uri != null && grant.grantee_id == null)
+    ])
+    error_message = "Grant grantee_type must be CanonicalUser with grantee_id, or Group with uri."
+  }
+
+  validation {
+    condition     = length(var.grants) == 0 || var.acl == null
+    error_message = "grants and acl cannot be combined. Express the canned ACL as grants instead."
+  }
+}
+
 variable "lifecycle_rules" {
-  description = "List of lifecycle rules for the bucket"
+  description = "List of lifecycle rules for the bucket. Rules without an id get one derived from a hash of the rule. Filter tags are a list of { key, value } objects, like the replication and intelligent tiering filters, rather than a map"
   type = list(object({
-    id      = string
+    id      = optional(string)
     status  = string
     filter = optional(object({
       prefix = optional(string)
@@ -129,29 +321,187 @@ variable "lifecycle_rules" {
         key   = string
         value = string
       })))
+      object_size_greater_than = optional(number)
+      object_size_less_than    = optional(number)
     }))
     transitions = optional(list(object({
       days          = number
       storage_class = string
     })))
     expiration = optional(object({
-      days = number
+      days                         = optional(number)
+      date                         = optional(string)
+      expired_object_delete_marker = optional(bool)
     }))
     noncurrent_version_transitions = optional(list(object({
       noncurrent_days = number
       storage_class   = string
     })))
     noncurrent_version_expiration = optional(object({
-      noncurrent_days = number
+      noncurrent_days           = number
+      newer_noncurrent_versions = optional(number)
     }))
     abort_incomplete_multipart_upload_days = optional(number)
   }))
   default = []
+
+  validation {
+    condition     = alltrue([for rule in var.lifecycle_rules : rule.id == null || try(trimspace(rule.id) != "", false)])
+    error_message = "Lifecycle rule ids must not be empty. Omit id to have one generated."
+  }
+
+  validation {
+    condition = length(var.lifecycle_rules) == length(distinct([
+      for rule in var.lifecycle_rules : rule.id != null ? rule.id : "rule-${substr(sha1(jsonencode(rule)), 0, 8)}"
+    ]))
+    error_message = "Lifecycle rule ids must be unique. Duplicated: ${join(", ", [
+      for id in distinct([for rule in var.lifecycle_rules : rule.id != null ? rule.id : "rule-${substr(sha1(jsonencode(rule)), 0, 8)}"]) : id
+      if length([for other in [for rule in var.lifecycle_rules : rule.id != null ? rule.id : "rule-${substr(sha1(jsonencode(rule)), 0, 8)}"] : other if other == id]) > 1
+    ])}."
+  }
+
+  validation {
+    condition     = alltrue([for rule in var.lifecycle_rules : contains(["Enabled", "Disabled"], rule.status)])
+    error_message = "Lifecycle rule status must be either 'Enabled' or 'Disabled'."
+  }
+
+  validation {
+    condition = alltrue(flatten([
+      for rule in var.lifecycle_rules : [
+        for transition in concat(
+          rule.transitions != null ? rule.transitions : [],
+          rule.noncurrent_version_transitions != null ? rule.noncurrent_version_transitions : []
+        ) : contains(["STANDARD_IA", "ONEZONE_IA", "INTELLIGENT_TIERING", "GLACIER", "DEEP_ARCHIVE", "GLACIER_IR"], transition.storage_class)
+      ]
+    ]))
+    error_message = "Lifecycle transition storage_class must be one of: STANDARD_IA, ONEZONE_IA, INTELLIGENT_TIERING, GLACIER, DEEP_ARCHIVE, GLACIER_IR."
+  }
+
+  validation {
+    condition = alltrue(flatten([
+      for rule in var.lifecycle_rules : concat(
+        [for transition in coalesce(rule.transitions, []) : !contains(["STANDARD_IA", "ONEZONE_IA"], transition.storage_class) || transition.days >= 30],
+        [for transition in coalesce(rule.noncurrent_version_transitions, []) : !contains(["STANDARD_IA", "ONEZONE_IA"], transition.storage_class) || transition.noncurrent_days >= 30]
+      )
+    ]))
+    error_message = "Lifecycle transitions to STANDARD_IA or ONEZONE_IA require at least 30 days (days or noncurrent_days); S3 rejects earlier transitions to these classes."
+  }
+
+  validation {
+    condition = alltrue(flatten([
+      for rule in var.lifecycle_rules : [
+        for pair in setproduct(
+          [for transition in coalesce(rule.transitions, []) : transition.days if contains(["STANDARD_IA", "ONEZONE_IA"], transition.storage_class)],
+          [for transition in coalesce(rule.transitions, []) : transition.days]
+        ) : pair[1] <= pair[0] || pair[1] - pair[0] >= 30
+      ]
+    ]))
+    error_message = "Lifecycle transitions after a STANDARD_IA or ONEZONE_IA transition must be at least 30 days later, because objects must stay in those classes for 30 days."
+  }
+
+  validation {
+    condition = alltrue([
+      for rule in var.lifecycle_rules : rule.noncurrent_version_expiration == null || alltrue([
+        for value in [rule.noncurrent_version_expiration.noncurrent_days, rule.noncurrent_version_expiration.newer_noncurrent_versions] :
+        value == null || try(value >= 0 && floor(value) == value, false)
+      ])
+    ])
+    error_message = "Lifecycle noncurrent_days and newer_noncurrent_versions must be non-negative integers."
+  }
+
+  validation {
+    condition = alltrue([
+      for rule in var.lifecycle_rules : rule.abort_incomplete_multipart_upload_days == null || try(rule.abort_incomplete_multipart_upload_days >= 1, false)
+    ])
+    error_message = "Lifecycle abort_incomplete_multipart_upload_days must be at least 1."
+  }
+
+  validation {
+    condition = alltrue([
+      for rule in var.lifecycle_rules : rule.abort_incomplete_multipart_upload_days == null || try(length(rule.filter.tags), 0) == 0
+    ])
+    error_message = "Lifecycle abort_incomplete_multipart_upload_days cannot be combined with a tag filter."
+  }
+
+  validation {
+    condition = alltrue([
+      for rule in var.lifecycle_rules : length(distinct([for tag in coalesce(try(rule.filter.tags, null), []) : tag.key])) == length(coalesce(try(rule.filter.tags, null), []))
+    ])
+    error_message = "Lifecycle filter tag keys must be unique within a rule."
+  }
+
+  validation {
+    condition = alltrue([
+      for rule in var.lifecycle_rules : alltrue([
+        for value in [try(rule.filter.object_size_greater_than, null), try(rule.filter.object_size_less_than, null)] :
+        value == null || try(value >= 0 && floor(value) == value, false)
+      ])
+    ])
+    error_message = "Lifecycle object_size_greater_than and object_size_less_than must be non-negative integers in bytes."
+  }
+
+  validation {
+    condition = alltrue([
+      for rule in var.lifecycle_rules : try(rule.filter.object_size_greater_than, null) == null || try(rule.filter.object_size_less_than, null) == null || try(rule.filter.object_size_greater_than < rule.filter.object_size_less_than, false)
+    ])
+    error_message = "Lifecycle object_size_greater_than must be less than object_size_less_than."
+  }
+
+  validation {
+    condition = alltrue([
+      for rule in var.lifecycle_rules : rule.expiration == null || length([
+        for value in [try(rule.expiration.days, null), try(rule.expiration.date, null), try(rule.expiration.expired_object_delete_marker, false) == true ? true : null] : value if value != null
+      ]) == 1
+    ])
+    error_message = "Lifecycle expiration must set exactly one of days, date or expired_object_delete_marker = true."
+  }
+
+  validation {
+    condition = alltrue([
+      for rule in var.lifecycle_rules : try(rule.expiration.date, null) == null || can(regex("^\\d{4}-\\d{2}-\\d{2}T00:00:00Z$", rule.expiration.date))
+    ])
+    error_message = "Lifecycle expiration date must be midnight UTC in RFC 3339 format, for example 2030-01-01T00:00:00Z."
+  }
+
+  validation {
+    condition = alltrue([
+      for rule in var.lifecycle_rules : try(rule.expiration.expired_object_delete_marker, false) != true || try(length(rule.filter.tags), 0) == 0
+    ])
+    error_message = "Lifecycle expiration expired_object_delete_marker = true cannot be combined with a tag filter."
+  }
+}
+
+variable "transition_default_minimum_object_size" {
+  description = "Minimum object size for lifecycle transitions: all_storage_classes_128K keeps objects under 128 KB from transitioning, varies_by_storage_class still lets them transition to GLACIER and DEEP_ARCHIVE. Null keeps the AWS default (all_storage_classes_128K)"
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.transition_default_minimum_object_size == null || contains(["varies_by_storage_class", "all_storage_classes_128K"], var.transition_default_minimum_object_size)
+    error_message = "transition_default_minimum_object_size must be either 'varies_by_storage_class' or 'all_storage_classes_128K'."
+  }
+}
+
+variable "abort_incomplete_multipart_upload_days" {
+  description = "Days after which incomplete multipart uploads are aborted. Adds an abort-incomplete-multipart-upload lifecycle rule covering the whole bucket, alongside any lifecycle_rules"
+  type        = number
+  default     = null
+
+  validation {
+    condition     = var.abort_incomplete_multipart_upload_days == null || try(var.abort_incomplete_multipart_upload_days >= 1 && floor(var.abort_incomplete_multipart_upload_days) == var.abort_incomplete_multipart_upload_days, false)
+    error_message = "abort_incomplete_multipart_upload_days must be a whole number of at least 1."
+  }
+
+  validation {
+    condition     = var.abort_incomplete_multipart_upload_days == null || !contains([for rule in var.lifecycle_rules : rule.id if rule.id != null], "abort-incomplete-multipart-upload")
+    error_message = "The lifecycle rule id 'abort-incomplete-multipart-upload' is reserved when abort_incomplete_multipart_upload_days is set."
+  }
 }
 
 variable "cors_rules" {
-  description = "List of CORS rules for the bucket"
+  description = "List of CORS rules for the bucket. S3 uses the first rule matching a request, so rules are applied in list order"
   type = list(object({
+    id              = optional(string)
     allowed_headers = list(string)
     allowed_methods = list(string)
     allowed_origins = list(string)
@@ -159,6 +509,25 @@ variable "cors_rules" {
     max_age_seconds = optional(number)
   }))
   default = []
+
+  validation {
+    condition = alltrue([
+      for rule in var.cors_rules : alltrue([
+        for method in rule.allowed_methods : contains(["GET", "PUT", "POST", "DELETE", "HEAD"], method)
+      ])
+    ])
+    error_message = "CORS allowed_methods must only contain: GET, PUT, POST, DELETE, HEAD."
+  }
+
+  validation {
+    condition     = alltrue([for rule in var.cors_rules : rule.id == null || try(length(rule.id) >= 1 && length(rule.id) <= 255, false)])
+    error_message = "CORS rule ids must be between 1 and 255 characters."
+  }
+
+  validation {
+    condition     = length(compact([for rule in var.cors_rules : rule.id])) == length(distinct(compact([for rule in var.cors_rules : rule.id])))
+    error_message = "CORS rule ids must be unique."
+  }
 }
 
 variable "website_configuration" {
@@ -170,12 +539,66 @@ variable "website_configuration" {
       host_name = string
       protocol  = optional(string)
     }))
+    routing_rules = optional(list(object({
+      condition = optional(object({
+        key_prefix_equals               = optional(string)
+        http_error_code_returned_equals = optional(string)
+      }))
+      redirect = object({
+        host_name               = optional(string)
+        http_redirect_code      = optional(string)
+        protocol                = optional(string)
+        replace_key_prefix_with = optional(string)
+        replace_key_with        = optional(string)
+      })
+    })), [])
   })
   default = null
+
+  validation {
+    condition     = var.website_configuration == null || try(var.website_configuration.redirect_all_requests_to, null) == null || length(try(var.website_configuration.routing_rules, [])) == 0
+    error_message = "Website routing_rules cannot be combined with redirect_all_requests_to."
+  }
+
+  validation {
+    condition = var.website_configuration == null || try(var.website_configuration.redirect_all_requests_to, null) == null || (
+      try(var.website_configuration.index_document, null) == null && try(var.website_configuration.error_document, null) == null
+    )
+    error_message = "Website redirect_all_requests_to cannot be combined with index_document or error_document."
+  }
+
+  validation {
+    condition     = var.website_configuration == null || try(var.website_configuration.redirect_all_requests_to.protocol, null) == null || contains(["http", "https"], try(var.website_configuration.redirect_all_requests_to.protocol, ""))
+    error_message = "Website redirect_all_requests_to protocol must be either 'http' or 'https'."
+  }
+
+  validation {
+    condition = var.website_configuration == null || alltrue([
+      for rule in try(var.website_configuration.routing_rules, []) : rule.redirect.replace_key_prefix_with == null || rule.redirect.replace_key_with == null
+    ])
+    error_message = "A website routing rule redirect cannot set both replace_key_prefix_with and replace_key_with."
+  }
+}
+
+variable "website_index_document" {
+  description = "Index document suffix used when website_configuration does not set index_document"
+  type        = string
+  default     = "index.html"
+
+  validation {
+    condition     = var.website_index_document == null || try(length(var.website_index_document) > 0 && !strcontains(var.website_index_document, "/"), false)
+    error_message = "website_index_document must be a non-empty suffix without slashes."
+  }
+}
+
+variable "website_error_document" {
+  description = "Error document key used when website_configuration does not set error_document. Set it to the index document for single-page app routing"
+  type        = string
+  default     = "error.html"
 }
 
 variable "notification_configuration" {
-  description = "Notification configuration for the bucket"
+  description = "Notification configuration for the bucket. The module grants S3 permission to invoke each Lambda function; function ARNs must be known at plan time. SNS topic policies are not managed and must allow the bucket to publish"
   type = object({
     lambda_functions = optional(list(object({
       lambda_function_arn = string
@@ -197,18 +620,38 @@ variable "notification_configuration" {
     })))
   })
   default = null
+
+  validation {
+    condition = alltrue([
+      for topic in (try(var.notification_configuration.topics, null) != null ? var.notification_configuration.topics : []) : alltrue([
+        for event in topic.events : startswith(event, "s3:")
+      ])
+    ])
+    error_message = "SNS topic notification events must be S3 event types starting with 's3:' (e.g., s3:ObjectRemoved:*)."
+  }
+}
+
+variable "eventbridge_enabled" {
+  description = "Whether to send all bucket events to Amazon EventBridge. Can be combined with notification_configuration destinations"
+  type        = bool
+  default     = false
 }
 
 variable "bucket_policy" {
   description = "The bucket policy as a JSON string"
   type        = string
   default     = null
+
+  validation {
+    condition     = var.bucket_policy == null || can(jsondecode(var.bucket_policy))
+    error_message = "Bucket policy must be a valid JSON document."
+  }
 }
 
 variable "replication_configuration" {
-  description = "Replication configuration for the bucket"
+  description = "Replication configuration for the bucket. When role is omitted, the module creates an IAM role with the required replication permissions"
   type = object({
-    role = string
+    role = optional(string)
     rules = list(object({
       id       = string
       status   = string
@@ -223,6 +666,7 @@ variable "replication_configuration" {
       destination = object({
         bucket        = string
         storage_class = optional(string)
+        account       = optional(string)
         replica_kms_key_id = optional(string)
         access_control_translation = optional(object({
           owner = string
@@ -236,6 +680,10 @@ variable "replication_configuration" {
             minutes = number
           }))
         }))
+        replication_time = optional(object({
+          status  = string
+          minutes = optional(number, 15)
+        }))
       })
       source_selection_criteria = optional(object({
         sse_kms_encrypted_objects = optional(object({
@@ -248,13 +696,85 @@ variable "replication_configuration" {
     }))
   })
   default = null
+
+  validation {
+    condition     = var.replication_configuration == null || var.versioning_status == "Enabled"
+    error_message = "Replication requires versioning on the source bucket. Set versioning_status = \"Enabled\" when replication_configuration is provided."
+  }
+
+  validation {
+    condition = var.replication_configuration == null || alltrue([
+      for rule in try(var.replication_configuration.rules, []) : (
+        (rule.destination.replica_kms_key_id == null && try(rule.destination.encryption_configuration.replica_kms_key_id, null) == null) ||
+        try(rule.source_selection_criteria.sse_kms_encrypted_objects.status, "Enabled") == "Enabled"
+      )
+    ])
+    error_message = "Replication rules with a replica KMS key cannot disable source_selection_criteria.sse_kms_encrypted_objects. It is enabled automatically when omitted."
+  }
+
+  validation {
+    condition = var.replication_configuration == null || alltrue([
+      for rule in try(var.replication_configuration.rules, []) : rule.destination.replication_time == null || try(rule.destination.replication_time.minutes == 15, false)
+    ])
+    error_message = "Replication Time Control only supports a threshold of 15 minutes."
+  }
+
+  validation {
+    condition = var.replication_configuration == null || alltrue([
+      for rule in try(var.replication_configuration.rules, []) : rule.delete_marker_replication == null || contains(["Enabled", "Disabled"], try(rule.delete_marker_replication.status, ""))
+    ])
+    error_message = "Replication delete_marker_replication status must be either 'Enabled' or 'Disabled'."
+  }
+
+  validation {
+    condition = var.replication_configuration == null || alltrue([
+      for rule in try(var.replication_configuration.rules, []) : rule.destination.account == null || can(regex("^[0-9]{12}$", rule.destination.account))
+    ])
+    error_message = "Replication destination account must be a 12-digit AWS account ID."
+  }
+
+  validation {
+    condition = var.replication_configuration == null || alltrue([
+      for rule in try(var.replication_configuration.rules, []) : rule.destination.access_control_translation == null || (
+        try(rule.destination.access_control_translation.owner, "") == "Destination" && rule.destination.account != null
+      )
+    ])
+    error_message = "Replication access_control_translation.owner must be \"Destination\" and requires destination.account to be set."
+  }
+
+  validation {
+    condition     = var.replication_configuration == null || length(distinct([for rule in try(var.replication_configuration.rules, []) : rule.id])) == length(try(var.replication_configuration.rules, []))
+    error_message = "Replication rule IDs must be unique."
+  }
+
+  validation {
+    condition = var.replication_configuration == null || alltrue([
+      for rule in try(var.replication_configuration.rules, []) : (
+        try(rule.destination.replication_time.status, "Disabled") != "Enabled" ||
+        try(rule.destination.metrics.status, "Enabled") == "Enabled"
+      )
+    ])
+    error_message = "Replication Time Control requires replication metrics. Leave destination.metrics unset or set its status to \"Enabled\"."
+  }
+}
+
+variable "replication_role_arn" {
+  description = "ARN of an existing IAM role for replication. When set, the module does not create a replication role or policy. replication_configuration.role takes precedence"
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.replication_role_arn == null || can(regex("^arn:aws[a-z-]*:iam::[0-9]{12}:role/[\\w+=,.@/-]+$", var.replication_role_arn))
+    error_message = "replication_role_arn must be an IAM role ARN such as arn:aws:iam::123456789012:role/s3-replication."
+  }
 }
 
 variable "intelligent_tiering_configurations" {
   description = "Intelligent tiering configurations for the bucket"
   type = list(object({
-    id   = string
-    name = string
+    id     = string
+    name   = string
+    status = optional(string, "Enabled")
     filter = optional(object({
       prefix = optional(string)
       tags   = optional(list(object({
@@ -268,10 +788,39 @@ variable "intelligent_tiering_configurations" {
     }))
   }))
   default = []
+
+  validation {
+    condition     = alltrue([for config in var.intelligent_tiering_configurations : contains(["Enabled", "Disabled"], config.status)])
+    error_message = "Intelligent tiering status must be either 'Enabled' or 'Disabled'."
+  }
+
+  validation {
+    condition = alltrue(flatten([
+      for config in var.intelligent_tiering_configurations : [
+        for tier in config.tiering : contains(["ARCHIVE_ACCESS", "DEEP_ARCHIVE_ACCESS"], tier.access_tier)
+      ]
+    ]))
+    error_message = "Intelligent tiering access_tier must be either 'ARCHIVE_ACCESS' or 'DEEP_ARCHIVE_ACCESS'."
+  }
+
+  validation {
+    condition = alltrue(flatten([
+      for config in var.intelligent_tiering_configurations : [
+        for tier in config.tiering : tier.days >= (tier.access_tier == "DEEP_ARCHIVE_ACCESS" ? 180 : 90) && tier.days <= 730
+      ]
+    ]))
+    error_message = "Intelligent tiering days must be between 90 and 730 for ARCHIVE_ACCESS and between 180 and 730 for DEEP_ARCHIVE_ACCESS."
+  }
+}
+
+variable "object_lock_enabled" {
+  description = "Whether object lock is enabled on the bucket. Can only be set when the bucket is created, and is implied by object_lock_configuration. Defaults to false, or true with the strict security_profile"
+  type        = bool
+  default     = null
 }
 
 variable "object_lock_configuration" {
-  description = "Object lock configuration for the bucket"
+  description = "Object lock configuration for the bucket. Requires versioning_status = \"Enabled\""
   type = object({
     rules = list(object({
       default_retention = object({
@@ -282,20 +831,502 @@ variable "object_lock_configuration" {
     }))
   })
   default = null
-} 
+
+  validation {
+    condition     = var.object_lock_configuration == null || length(try(var.object_lock_configuration.rules, [])) == 1
+    error_message = "Object lock configuration must contain exactly one rule."
+  }
+
+  validation {
+    condition = var.object_lock_configuration == null || alltrue([
+      for rule in try(var.object_lock_configuration.rules, []) : contains(["COMPLIANCE", "GOVERNANCE"], rule.default_retention.mode)
+    ])
+    error_message = "Object lock retention mode must be either 'COMPLIANCE' or 'GOVERNANCE'."
+  }
+
+  validation {
+    condition = var.object_lock_configuration == null || alltrue([
+      for rule in try(var.object_lock_configuration.rules, []) : (rule.default_retention.days == null) != (rule.default_retention.years == null)
+    ])
+    error_message = "Object lock default retention must set exactly one of days or years."
+  }
+
+  validation {
+    condition     = var.object_lock_configuration == null || var.versioning_status == "Enabled"
+    error_message = "Object lock requires versioning. Set versioning_status = \"Enabled\" when object_lock_configuration is provided."
+  }
+}
+
 # ==============================================================================
 # Enhanced S3 Configuration Variables
 # ==============================================================================
 
 variable "force_destroy" {
-  description = "Whether to force destroy the bucket even if it contains objects"
+  description = "Whether to delete all objects when the bucket is destroyed. Keep false for production buckets"
+  type        = bool
+  default     = false
+}
+
+variable "logging" {
+  description = "Server access logging configuration. The target bucket is not created by this module and must allow log delivery from this bucket. target_object_key_format is simple or partitioned; partitioned keys are grouped by partition_date_source (EventTime or DeliveryTime)"
+  type = object({
+    target_bucket            = string
+    target_prefix            = optional(string, "")
+    target_object_key_format = optional(string, "simple")
+    partition_date_source    = optional(string, "EventTime")
+  })
+  default = null
+
+  validation {
+    condition     = var.logging == null || contains(["simple", "partitioned"], try(var.logging.target_object_key_format, ""))
+    error_message = "Logging target_object_key_format must be either 'simple' or 'partitioned'."
+  }
+
+  validation {
+    condition     = var.logging == null || contains(["EventTime", "DeliveryTime"], try(var.logging.partition_date_source, ""))
+    error_message = "Logging partition_date_source must be either 'EventTime' or 'DeliveryTime'."
+  }
+}
+
+variable "inventory_configurations" {
+  description = "Inventory configurations keyed by configuration name. The destination bucket must allow S3 to write inventory reports"
+  type = map(object({
+    enabled                  = optional(bool, true)
+    included_object_versions = optional(string, "Current")
+    schedule_frequency       = optional(string, "Daily")
+    destination_bucket_arn   = string
+    destination_prefix       = optional(string)
+    destination_format       = optional(string, "CSV")
+    # Encryption of the report files: sse_s3 = {} or sse_kms = { key_id = "<key ARN>" }
+    destination_encryption = optional(object({
+      sse_s3 = optional(object({}))
+      sse_kms = optional(object({
+        key_id = optional(string)
+      }))
+    }))
+    optional_fields = optional(list(string), [])
+  }))
+  default = {}
+
+  validation {
+    condition     = alltrue([for config in values(var.inventory_configurations) : contains(["All", "Current"], config.included_object_versions)])
+    error_message = "Inventory included_object_versions must be either 'All' or 'Current'."
+  }
+
+  validation {
+    condition     = alltrue([for config in values(var.inventory_configurations) : contains(["Daily", "Weekly"], config.schedule_frequency)])
+    error_message = "Inventory schedule_frequency must be either 'Daily' or 'Weekly'."
+  }
+
+  validation {
+    condition     = alltrue([for config in values(var.inventory_configurations) : contains(["CSV", "ORC", "Parquet"], config.destination_format)])
+    error_message = "Inventory destination_format must be one of: CSV, ORC, Parquet."
+  }
+
+  validation {
+    condition = alltrue([
+      for config in values(var.inventory_configurations) : config.destination_encryption == null || (try(config.destination_encryption.sse_s3, null) == null) != (try(config.destination_encryption.sse_kms, null) == null)
+    ])
+    error_message = "Inventory destination_encryption must set exactly one of sse_s3 or sse_kms."
+  }
+
+  validation {
+    condition = alltrue([
+      for config in values(var.inventory_configurations) : try(config.destination_encryption.sse_kms, null) == null || can(regex("^arn:aws[a-z-]*:kms:", config.destination_encryption.sse_kms.key_id))
+    ])
+    error_message = "Inventory destination_encryption.sse_kms requires key_id set to a KMS key ARN."
+  }
+}
+
+variable "metrics_configurations" {
+  description = "CloudWatch request metrics configurations for the bucket"
+  type = list(object({
+    id            = string
+    filter_prefix = optional(string)
+    filter_tags   = optional(map(string))
+  }))
+  default = []
+}
+
+variable "create_request_alarms" {
+  description = "Whether to create CloudWatch alarms on the 4xxErrors and 5xxErrors request metrics of each metrics_configurations entry. No alarms are created without request metrics"
   type        = bool
   default     = false
 }
 
-variable "prevent_destroy" {
-  description = "Prevent destruction of the bucket (use with caution)"
+variable "alarm_4xx_threshold" {
+  description = "Number of 4xx errors in five minutes above which the 4xx request alarm fires"
+  type        = number
+  default     = 100
+
+  validation {
+    condition     = var.alarm_4xx_threshold >= 0
+    error_message = "alarm_4xx_threshold must not be negative."
+  }
+}
+
+variable "alarm_5xx_threshold" {
+  description = "Number of 5xx errors in five minutes above which the 5xx request alarm fires"
+  type        = number
+  default     = 10
+
+  validation {
+    condition     = var.alarm_5xx_threshold >= 0
+    error_message = "alarm_5xx_threshold must not be negative."
+  }
+}
+
+variable "create_replication_latency_alarm" {
+  description = "Whether to create a CloudWatch alarm on the ReplicationLatency metric of each replication rule with replication metrics enabled. Metrics are enabled by destination.metrics or by Replication Time Control"
   type        = bool
   default     = false
+
+  validation {
+    condition = !var.create_replication_latency_alarm || length([
+      for rule in try(var.replication_configuration.rules, []) : rule.id
+      if try(rule.destination.metrics.status, null) == "Enabled" || (try(rule.destination.metrics, null) == null && try(rule.destination.replication_time.status, "Disabled") == "Enabled")
+    ]) > 0
+    error_message = "create_replication_latency_alarm requires a replication rule with replication metrics enabled, through destination.metrics or replication_time."
+  }
+}
+
+variable "replication_latency_threshold_seconds" {
+  description = "Replication latency in seconds above which the replication latency alarm fires. The default matches the 15 minute Replication Time Control threshold"
+  type        = number
+  default     = 900
+
+  validation {
+    condition     = var.replication_latency_threshold_seconds > 0
+    error_message = "replication_latency_threshold_seconds must be greater than 0."
+  }
+}
+
+variable "acceleration_status" {
+  description = "Transfer acceleration status for the bucket (Enabled or Suspended). Leave null to skip the accelerate configuration"
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.acceleration_status == null || contains(["Enabled", "Suspended"], var.acceleration_status)
+    error_message = "Acceleration status must be either 'Enabled' or 'Suspended'."
+  }
+
+  validation {
+    condition     = var.acceleration_status != "Enabled" || !strcontains(join("", compact([var.bucket_name, var.bucket_prefix])), ".")
+    error_message = "Transfer acceleration requires a DNS-compliant bucket name without dots."
+  }
+}
+
+variable "request_payer" {
+  description = "Who pays for requests and data transfer (BucketOwner or Requester)"
+  type        = string
+  default     = "BucketOwner"
+
+  validation {
+    condition     = contains(["BucketOwner", "Requester"], var.request_payer)
+    error_message = "Request payer must be either 'BucketOwner' or 'Requester'."
+  }
+}
+
+variable "create_kms_key" {
+  description = "Whether to create a dedicated KMS key for SSE-KMS instead of using kms_key_arn. Defaults to false, or true with the strict security_profile when no key is supplied"
+  type        = bool
+  default     = null
+}
+
+variable "created_kms_key_alias" {
+  description = "Alias for the created KMS key. Defaults to alias/<bucket_name>"
+  type        = string
+  default     = null
+}
+
+variable "kms_key_deletion_window_in_days" {
+  description = "Waiting period in days before the created KMS key is deleted"
+  type        = number
+  default     = 30
+
+  validation {
+    condition     = var.kms_key_deletion_window_in_days >= 7 && var.kms_key_deletion_window_in_days <= 30
+    error_message = "KMS key deletion window must be between 7 and 30 days."
+  }
+}
+
+variable "kms_key_enable_rotation" {
+  description = "Whether automatic yearly rotation is enabled for the created KMS key"
+  type        = bool
+  default     = true
 }
 
+variable "kms_key_tags" {
+  description = "Additional tags for the created KMS key only, merged over the bucket tags"
+  type        = map(string)
+  default     = {}
+}
+
+variable "kms_key_user_principals" {
+  description = "IAM ARNs allowed to encrypt and decrypt with the created KMS key through its key policy, such as replication roles writing replicas into this bucket. Principals in other accounts also need the permissions in their own IAM policy"
+  type        = list(string)
+  default     = []
+
+  validation {
+    condition     = alltrue([for principal in var.kms_key_user_principals : can(regex("^arn:aws[a-z-]*:iam::[0-9]{12}:(root|role/.+|user/.+)$", principal))])
+    error_message = "KMS key user principals must be IAM account root, role or user ARNs."
+  }
+}
+
+variable "enforce_ssl" {
+  description = "Whether to deny requests that do not use TLS. The deny statement is merged into bucket_policy when one is supplied. Defaults to false, or true with the baseline and strict security_profile"
+  type        = bool
+  default     = null
+}
+
+variable "enforce_min_tls_version" {
+  description = "Minimum TLS version (1.2 or 1.3) for requests to the bucket. Leave null to allow any version, or 1.2 with the strict security_profile"
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.enforce_min_tls_version == null || contains(["1.2", "1.3"], var.enforce_min_tls_version)
+    error_message = "Minimum TLS version must be either '1.2' or '1.3'."
+  }
+}
+
+variable "deny_unencrypted_uploads" {
+  description = "Deny s3:PutObject requests that do not send the x-amz-server-side-encryption header. Other services writing to the bucket, such as log delivery, must then send it too"
+  type        = bool
+  default     = false
+}
+
+variable "required_upload_sse_algorithm" {
+  description = "Server-side encryption algorithm uploads must request (AES256, aws:kms or aws:kms:dsse). Requires deny_unencrypted_uploads"
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.required_upload_sse_algorithm == null || contains(["AES256", "aws:kms", "aws:kms:dsse"], var.required_upload_sse_algorithm)
+    error_message = "required_upload_sse_algorithm must be one of: AES256, aws:kms, aws:kms:dsse."
+  }
+
+  validation {
+    condition     = var.required_upload_sse_algorithm == null || var.deny_unencrypted_uploads
+    error_message = "required_upload_sse_algorithm requires deny_unencrypted_uploads = true."
+  }
+}
+
+variable "required_upload_kms_key_arn" {
+  description = "KMS key ARN uploads must name in x-amz-server-side-encryption-aws-kms-key-id. Requires deny_unencrypted_uploads"
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.required_upload_kms_key_arn == null || can(regex("^arn:aws[a-z-]*:kms:", var.required_upload_kms_key_arn))
+    error_message = "required_upload_kms_key_arn must be a KMS key ARN."
+  }
+
+  validation {
+    condition     = var.required_upload_kms_key_arn == null || var.deny_unencrypted_uploads
+    error_message = "required_upload_kms_key_arn requires deny_unencrypted_uploads = true."
+  }
+}
+
+variable "cloudfront_oai_iam_arns" {
+  description = "IAM ARNs of CloudFront origin access identities granted s3:GetObject through the bucket policy"
+  type        = list(string)
+  default     = []
+
+  validation {
+    condition     = alltrue([for arn in var.cloudfront_oai_iam_arns : can(regex("^arn:aws:iam::cloudfront:user/CloudFront Origin Access Identity ", arn))])
+    error_message = "CloudFront OAI ARNs must look like 'arn:aws:iam::cloudfront:user/CloudFront Origin Access Identity <id>'."
+  }
+}
+
+variable "cloudfront_distribution_arns" {
+  description = "ARNs of CloudFront distributions using origin access control, granted s3:GetObject through the bucket policy"
+  type        = list(string)
+  default     = []
+
+  validation {
+    condition     = alltrue([for arn in var.cloudfront_distribution_arns : can(regex("^arn:aws:cloudfront::[0-9]{12}:distribution/", arn))])
+    error_message = "CloudFront distribution ARNs must look like 'arn:aws:cloudfront::<account-id>:distribution/<id>'."
+  }
+}
+
+variable "restrict_to_vpc_endpoints" {
+  description = "VPC endpoint IDs that object reads, writes, deletes and listings must come through. Requests from anywhere else are denied"
+  type        = list(string)
+  default     = []
+
+  validation {
+    condition     = alltrue([for id in var.restrict_to_vpc_endpoints : can(regex("^vpce-[0-9a-f]+$", id))])
+    error_message = "VPC endpoint IDs must start with 'vpce-' followed by hexadecimal characters."
+  }
+}
+
+variable "encryption" {
+  description = "Default encryption settings. When set, takes the place of encryption_algorithm, kms_key_arn and bucket_key_enabled"
+  type = object({
+    sse_algorithm      = optional(string, "AES256")
+    kms_master_key_id  = optional(string)
+    bucket_key_enabled = optional(bool, true)
+  })
+  default = null
+
+  validation {
+    condition     = var.encryption == null || contains(["AES256", "aws:kms"], try(var.encryption.sse_algorithm, ""))
+    error_message = "encryption.sse_algorithm must be either 'AES256' or 'aws:kms'. SSE-C cannot be a bucket default; customer-provided keys are sent with each request."
+  }
+
+  validation {
+    condition     = try(var.encryption.sse_algorithm, null) != "AES256" || try(var.encryption.kms_master_key_id, null) == null
+    error_message = "encryption.kms_master_key_id must be empty when encryption.sse_algorithm is 'AES256'."
+  }
+
+  validation {
+    condition     = try(var.encryption.kms_master_key_id, null) == null || can(regex("^arn:aws:kms:", var.encryption.kms_master_key_id))
+    error_message = "encryption.kms_master_key_id must be a valid KMS key ARN."
+  }
+}
+
+variable "create_storage_lens_configuration" {
+  description = "Whether to create an S3 Storage Lens dashboard scoped to this bucket. Storage Lens configurations are account-level resources"
+  type        = bool
+  default     = false
+}
+
+variable "storage_lens" {
+  description = "Storage Lens dashboard settings used when create_storage_lens_configuration is true. Activity metrics are paid advanced metrics"
+  type = object({
+    id      = string
+    enabled = optional(bool, true)
+    account_level = optional(object({
+      activity_metrics_enabled              = optional(bool, false)
+      bucket_level_activity_metrics_enabled = optional(bool, false)
+    }), {})
+  })
+  default = null
+
+  validation {
+    condition     = !var.create_storage_lens_configuration || var.storage_lens != null
+    error_message = "storage_lens must be set when create_storage_lens_configuration is true."
+  }
+
+  validation {
+    condition     = var.storage_lens == null || can(regex("^[a-zA-Z0-9._-]{1,64}$", try(var.storage_lens.id, "")))
+    error_message = "storage_lens.id must be 1-64 characters of letters, numbers, dots, hyphens and underscores."
+  }
+}
+
+variable "access_points" {
+  description = "Access points keyed by name. Setting vpc_id limits an access point to requests from that VPC"
+  type = map(object({
+    vpc_id                  = optional(string)
+    policy                  = optional(string)
+    block_public_acls       = optional(bool, true)
+    block_public_policy     = optional(bool, true)
+    ignore_public_acls      = optional(bool, true)
+    restrict_public_buckets = optional(bool, true)
+  }))
+  default = {}
+
+  validation {
+    condition     = alltrue([for name in keys(var.access_points) : can(regex("^[a-z0-9][a-z0-9-]{1,48}[a-z0-9]$", name))])
+    error_message = "Access point names must be 3-50 characters of lowercase letters, numbers and hyphens, starting and ending with a letter or number."
+  }
+
+  validation {
+    condition     = alltrue([for access_point in values(var.access_points) : access_point.vpc_id == null || can(regex("^vpc-[0-9a-f]+$", access_point.vpc_id))])
+    error_message = "Access point vpc_id must start with 'vpc-' followed by hexadecimal characters."
+  }
+
+  validation {
+    condition     = alltrue([for access_point in values(var.access_points) : access_point.policy == null || can(jsondecode(access_point.policy))])
+    error_message = "Access point policies must be valid JSON documents."
+  }
+}
+
+variable "object_lambda_access_points" {
+  description = "Object Lambda access points keyed by name. supporting_access_point names an entry in access_points; when omitted or not declared there, the module creates it with defaults"
+  type = map(object({
+    lambda_function_arn         = string
+    function_payload            = optional(string)
+    supporting_access_point     = optional(string)
+    actions                     = optional(list(string), ["GetObject"])
+    allowed_features            = optional(list(string), [])
+    cloud_watch_metrics_enabled = optional(bool, false)
+  }))
+  default = {}
+
+  validation {
+    condition     = alltrue([for name in keys(var.object_lambda_access_points) : can(regex("^[a-z0-9][a-z0-9-]{1,43}[a-z0-9]$", name))])
+    error_message = "Object Lambda access point names must be 3-45 characters of lowercase letters, numbers and hyphens, starting and ending with a letter or number."
+  }
+
+  validation {
+    condition     = alltrue([for olap in values(var.object_lambda_access_points) : can(regex("^arn:aws:lambda:", olap.lambda_function_arn))])
+    error_message = "Object Lambda lambda_function_arn must be a Lambda function ARN."
+  }
+
+  validation {
+    condition = alltrue(flatten([
+      for olap in values(var.object_lambda_access_points) : [
+        for action in olap.actions : contains(["GetObject", "HeadObject", "ListObjects", "ListObjectsV2"], action)
+      ]
+    ]))
+    error_message = "Object Lambda actions must be GetObject, HeadObject, ListObjects or ListObjectsV2."
+  }
+
+  validation {
+    condition = alltrue(flatten([
+      for olap in values(var.object_lambda_access_points) : [
+        for feature in olap.allowed_features : contains(["GetObject-Range", "GetObject-PartNumber", "HeadObject-Range", "HeadObject-PartNumber"], feature)
+      ]
+    ]))
+    error_message = "Object Lambda allowed_features must be GetObject-Range, GetObject-PartNumber, HeadObject-Range or HeadObject-PartNumber."
+  }
+}
+
+variable "analytics_configurations" {
+  description = "Storage class analysis configurations keyed by name. The export destination bucket must allow S3 to write reports"
+  type = map(object({
+    filter_prefix = optional(string)
+    filter_tags   = optional(map(string))
+    export = optional(object({
+      destination_bucket_arn = string
+      prefix                 = optional(string)
+      format                 = optional(string, "CSV")
+    }))
+  }))
+  default = {}
+
+  validation {
+    condition     = alltrue([for config in values(var.analytics_configurations) : config.export == null || try(config.export.format == "CSV", false)])
+    error_message = "Analytics export format must be 'CSV'."
+  }
+
+  validation {
+    condition     = alltrue([for config in values(var.analytics_configurations) : config.export == null || can(regex("^arn:aws[a-z-]*:s3:::", config.export.destination_bucket_arn))])
+    error_message = "Analytics export destination_bucket_arn must be an S3 bucket ARN."
+  }
+}
+
+variable "cross_account_read_principals" {
+  description = "AWS account IDs or IAM ARNs granted s3:GetObject and s3:ListBucket through the bucket policy"
+  type        = list(string)
+  default     = []
+
+  validation {
+    condition     = alltrue([for principal in var.cross_account_read_principals : can(regex("^([0-9]{12}|arn:aws[a-z-]*:iam::[0-9]{12}:.+)$", principal))])
+    error_message = "Cross-account read principals must be 12-digit account IDs or IAM ARNs."
+  }
+}
+
+variable "object_lock_governance_bypass_principals" {
+  description = "IAM ARNs granted s3:BypassGovernanceRetention through the bucket policy, so they can shorten or remove GOVERNANCE mode retention. They still need the permission in their own IAM policy"
+  type        = list(string)
+  default     = []
+
+  validation {
+    condition     = alltrue([for principal in var.object_lock_governance_bypass_principals : can(regex("^arn:aws[a-z-]*:iam::[0-9]{12}:(root|role/.+|user/.+)$", principal))])
+    error_message = "Governance bypass principals must be IAM account root, role or user ARNs."
+  }
+}